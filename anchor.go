@@ -0,0 +1,125 @@
+package wordclouds
+
+import (
+	"image"
+	"math"
+)
+
+// Anchor is where the words are moved on the canvas once laid out
+type Anchor int
+
+const (
+	// The words stay where they were placed, around the center
+	AnchorCenter Anchor = iota
+	AnchorTopLeft
+	AnchorTop
+	AnchorTopRight
+	AnchorLeft
+	AnchorRight
+	AnchorBottomLeft
+	AnchorBottom
+	AnchorBottomRight
+)
+
+// anchorShift returns how far the words must move for the box around them, descenders included, to touch the
+// anchored sides of the canvas within EdgeMargin
+func (w *Wordcloud) anchorShift() (dx float64, dy float64) {
+	left, top := math.Inf(1), math.Inf(1)
+	right, bottom := math.Inf(-1), math.Inf(-1)
+	for _, pw := range w.placed {
+		// In image coordinates, the top of the canvas is the bottom of the boxes
+		left, top = math.Min(left, pw.bounds.Left), math.Min(top, pw.bounds.Bottom)
+		right, bottom = math.Max(right, pw.bounds.Right), math.Max(bottom, pw.bounds.Top)
+	}
+	margin := w.opts.EdgeMargin
+	switch w.opts.Anchor {
+	case AnchorTopLeft, AnchorLeft, AnchorBottomLeft:
+		dx = margin - left
+	case AnchorTopRight, AnchorRight, AnchorBottomRight:
+		dx = w.width - margin - right
+	}
+	switch w.opts.Anchor {
+	case AnchorTopLeft, AnchorTop, AnchorTopRight:
+		dy = margin - top
+	case AnchorBottomLeft, AnchorBottom, AnchorBottomRight:
+		dy = w.height - margin - bottom
+	}
+	return dx, dy
+}
+
+// limitShift returns how far the words can move toward d, pixel by pixel up to d, while clear tells they are clear at
+// that distance
+func limitShift(d float64, clear func(d float64) bool) float64 {
+	step := math.Copysign(1, d)
+	last := 0.0
+	for s := step; math.Abs(s) < math.Abs(d); s += step {
+		if !clear(s) {
+			return last
+		}
+		last = s
+	}
+	if !clear(d) {
+		return last
+	}
+	return d
+}
+
+// shiftClear tells whether the words moved by dx, dy are clear of the masks, the title and the watermark in the
+// collision grid, and inside the region if any
+func (w *Wordcloud) shiftClear(dx float64, dy float64) bool {
+	var moved Box
+	for _, pw := range w.placed {
+		for _, b := range pw.boxes {
+			moved = Box{b.Top + dy, b.Left + dx, b.Right + dx, b.Bottom + dy}
+			if colliding, _ := w.grid.TestCollision(&moved, (*Box).overlaps); colliding {
+				return false
+			}
+		}
+		moved = Box{pw.bounds.Top + dy, pw.bounds.Left + dx, pw.bounds.Right + dx, pw.bounds.Bottom + dy}
+		if len(w.opts.Region) >= 3 && !inPolygon(&moved, w.opts.Region) {
+			return false
+		}
+	}
+	return true
+}
+
+// anchorWords moves the placed words to the Anchor and draws them again over base, the canvas before any word. The
+// words stop short of the masks, the title, the watermark and the edges of the region on their way, horizontally then
+// vertically.
+func (w *Wordcloud) anchorWords(base image.Image) {
+	if w.opts.Anchor == AnchorCenter || len(w.placed) == 0 {
+		return
+	}
+	w.resetGrid()
+	dx, dy := w.anchorShift()
+	blocked := len(w.opts.Mask) > 0 || w.opts.Title != "" || w.opts.Watermark.Text != "" || len(w.opts.Region) >= 3
+	if blocked && !w.opts.NoCollision {
+		// The grid only holds the masks and bands
+		dx = limitShift(dx, func(d float64) bool { return w.shiftClear(d, 0) })
+		dy = limitShift(dy, func(d float64) bool { return w.shiftClear(dx, d) })
+	}
+
+	// Boxes may be shared by the fields of a word
+	shifted := make(map[*Box]bool)
+	shift := func(b *Box) {
+		if b == nil || shifted[b] {
+			return
+		}
+		shifted[b] = true
+		b.Top, b.Left, b.Right, b.Bottom = b.Top+dy, b.Left+dx, b.Right+dx, b.Bottom+dy
+	}
+	for i := range w.placed {
+		pw := &w.placed[i]
+		pw.x += dx
+		pw.y += dy
+		shift(pw.box)
+		shift(pw.bounds)
+		for _, b := range pw.boxes {
+			shift(b)
+			if !w.opts.NoCollision {
+				w.grid.Add(b)
+			}
+		}
+	}
+	w.redrawWords(base)
+}
@@ -0,0 +1,93 @@
+package wordclouds
+
+import (
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_AnchorCloud(t *testing.T) {
+	words := map[string]int{"anchored": 10, "cloud": 6, "corner": 4, "words": 3}
+	for _, anchor := range []Anchor{AnchorTopLeft, AnchorBottomRight} {
+		w := newTestCloud(t, words,
+			FontMaxSize(40),
+			EdgeMargin(10),
+			AnchorCloud(anchor),
+		)
+		img := w.Draw()
+		assert.Len(t, w.placed, 4)
+
+		left, top, right, bottom := 400.0, 400.0, 0.0, 0.0
+		for _, pw := range w.placed {
+			left, top = math.Min(left, pw.bounds.Left), math.Min(top, pw.bounds.Bottom)
+			right, bottom = math.Max(right, pw.bounds.Right), math.Max(bottom, pw.bounds.Top)
+		}
+		// The other corner of the canvas is empty
+		var corner color.Color
+		if anchor == AnchorTopLeft {
+			assert.InDelta(t, 10, left, 1e-9)
+			assert.InDelta(t, 10, top, 1e-9)
+			corner = img.At(390, 390)
+		} else {
+			assert.InDelta(t, 390, right, 1e-9)
+			assert.InDelta(t, 390, bottom, 1e-9)
+			corner = img.At(10, 10)
+		}
+		assert.Equal(t, color.RGBAModel.Convert(color.White), color.RGBAModel.Convert(corner))
+		assert.Empty(t, w.VerifyNoOverlap())
+
+		// Words placed afterwards avoid the moved ones
+		assert.True(t, w.Place(wordCount{word: "more", count: 3, size: 30, tier: -1}))
+		assert.Empty(t, w.VerifyNoOverlap())
+	}
+}
+
+func TestWordcloud_AnchorCloudBlocked(t *testing.T) {
+	words := map[string]int{"anchored": 10, "cloud": 6, "corner": 4, "words": 3}
+	mask := &Box{400, 0, 60, 0}
+	w := newTestCloud(t, words,
+		FontMaxSize(40),
+		MaskBoxes([]*Box{mask}),
+		Title("Title", TitleOptions{}),
+		AnchorCloud(AnchorTopLeft),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, 4)
+
+	// The words stop at the mask and below the title
+	title := w.titleBox()
+	left, top := 400.0, 400.0
+	for _, pw := range w.placed {
+		left, top = math.Min(left, pw.bounds.Left), math.Min(top, pw.bounds.Bottom)
+		for _, b := range pw.boxes {
+			assert.False(t, b.overlaps(mask), pw.word)
+			assert.False(t, b.overlaps(title), pw.word)
+		}
+	}
+	assert.InDelta(t, 60, left, 2)
+	assert.InDelta(t, title.Top, top, 2)
+}
+
+func TestWordcloud_AnchorCloudCanvas(t *testing.T) {
+	dc := gg.NewContext(400, 400)
+	w := newTestCloud(t, map[string]int{"anchored": 10, "cloud": 6},
+		FontMaxSize(40),
+		MaskBoxes([]*Box{{400, 360, 400, 360}}),
+		Debug(),
+		WithContext(dc),
+		AnchorCloud(AnchorTopLeft),
+	)
+	red := color.RGBA{R: 0xff, A: 0xff}
+	dc.SetColor(red)
+	dc.DrawRectangle(0, 380, 20, 20)
+	dc.Fill()
+	img := w.Draw()
+	assert.Len(t, w.placed, 2)
+
+	// What was on the canvas before Draw is kept: the outline of the mask and the drawing on the context
+	assert.NotEqual(t, color.RGBAModel.Convert(color.White), color.RGBAModel.Convert(img.At(360, 380)))
+	assert.Equal(t, color.RGBAModel.Convert(red), color.RGBAModel.Convert(img.At(10, 390)))
+}
@@ -0,0 +1,55 @@
+package wordclouds
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PlacementAttempt is a call to Place recorded with RecordAttempts
+type PlacementAttempt struct {
+	Word string
+	// Font size the word was tried at
+	Size float64
+	// Number of positions tested
+	Tries int
+	// Distance of the word to the center of the canvas, negative if it was not placed
+	Radius float64
+	Placed bool
+}
+
+// Attempts returns the placement attempts recorded with RecordAttempts since the last Reset, in order. Words retried
+// by RetryPasses have an attempt per pass.
+func (w *Wordcloud) Attempts() []PlacementAttempt {
+	return w.attempts
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteAttemptsDOT writes the placement attempts recorded with RecordAttempts as a Graphviz graph: a chain of one node
+// per attempt in placement order, green for the placed words and red for the others, labeled with the word, its size,
+// the number of positions tested and its distance to the center. Render it with e.g. dot -Tsvg.
+func (w *Wordcloud) WriteAttemptsDOT(out io.Writer) error {
+	_, err := fmt.Fprintln(out, "digraph attempts {\n  rankdir=LR;\n  node [shape=box, style=filled];")
+	if err != nil {
+		return err
+	}
+	for i, a := range w.attempts {
+		color, radius := "palegreen", fmt.Sprintf("r %.1f", a.Radius)
+		if !a.Placed {
+			color, radius = "lightpink", "dropped"
+		}
+		_, err = fmt.Fprintf(out, "  a%d [label=\"%s\\nsize %.1f, %d tries, %s\", fillcolor=%s];\n",
+			i, dotEscaper.Replace(a.Word), a.Size, a.Tries, radius, color)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = fmt.Fprintf(out, "  a%d -> a%d;\n", i-1, i); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = fmt.Fprintln(out, "}")
+	return err
+}
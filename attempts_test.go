@@ -0,0 +1,52 @@
+package wordclouds
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_RecordAttempts(t *testing.T) {
+	words := map[string]int{"first": 10, "second": 8, "\"quoted\"": 6, "muchtoolongtofitinthecanvas": 5}
+	w := newTestCloud(t, words,
+		FontMaxSize(30),
+		Width(200),
+		Height(200),
+		RecordAttempts(true),
+	)
+	w.Draw()
+
+	attempts := w.Attempts()
+	assert.Len(t, attempts, 4)
+	assert.Len(t, w.placed, 3)
+	placed := 0
+	for i, a := range attempts {
+		assert.Equal(t, w.sortedWordList[i].word, a.Word)
+		if !a.Placed {
+			// Too large for the canvas, no position is tested
+			assert.Equal(t, "muchtoolongtofitinthecanvas", a.Word)
+			assert.Equal(t, 0, a.Tries)
+			assert.Equal(t, -1.0, a.Radius)
+			continue
+		}
+		assert.Greater(t, a.Tries, 0)
+		pw := w.placed[placed]
+		placed++
+		assert.Equal(t, pw.word, a.Word)
+		assert.InDelta(t, math.Hypot(pw.x-100, pw.y-100), a.Radius, 1e-9)
+	}
+
+	var out strings.Builder
+	assert.NoError(t, w.WriteAttemptsDOT(&out))
+	dot := out.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph attempts {"))
+	assert.Contains(t, dot, `a2 [label="\"quoted\"\nsize`)
+	assert.Contains(t, dot, "a0 -> a1;")
+	assert.Contains(t, dot, "a2 -> a3;")
+	assert.Contains(t, dot, "dropped\", fillcolor=lightpink")
+
+	w.Reset(words)
+	assert.Empty(t, w.Attempts())
+}
@@ -0,0 +1,95 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+const (
+	// Saturation of the colors of AutoPalette
+	autoPaletteSaturation = 0.65
+	// Lightness of the colors of AutoPalette on light and dark backgrounds
+	autoPaletteDark  = 0.35
+	autoPaletteLight = 0.7
+)
+
+// autoPalette returns n colors of evenly spread hues, starting from the complement of the background hue, dark on
+// light backgrounds and light on dark ones, each contrasting enough with the background
+func autoPalette(n int, bg color.Color) []color.Color {
+	hue, _, _ := toHSL(bg)
+	lightness := autoPaletteLight
+	if luminance(bg) > 0.5 {
+		lightness = autoPaletteDark
+	}
+	colors := make([]color.Color, 0, n)
+	for i := 0; i < n; i++ {
+		h := math.Mod(hue+0.5+float64(i)/float64(n), 1)
+		colors = append(colors, withContrast(fromHSL(h, autoPaletteSaturation, lightness), bg))
+	}
+	return colors
+}
+
+// averageColor returns the average color of the image
+func averageColor(img image.Image) color.Color {
+	var r, g, b, n uint64
+	bounds := img.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			pr, pg, pb, _ := img.At(x, y).RGBA()
+			r, g, b, n = r+uint64(pr>>8), g+uint64(pg>>8), b+uint64(pb>>8), n+1
+		}
+	}
+	if n == 0 {
+		return color.Black
+	}
+	return color.RGBA{uint8(r / n), uint8(g / n), uint8(b / n), 0xff}
+}
+
+// toHSL returns the hue, saturation and lightness of the color, from 0 to 1
+func toHSL(c color.Color) (h float64, s float64, l float64) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	r, g, b := float64(n.R)/0xff, float64(n.G)/0xff, float64(n.B)/0xff
+	high, low := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (high + low) / 2
+	if high == low {
+		return 0, 0, l
+	}
+	d := high - low
+	s = d / (1 - math.Abs(2*l-1))
+	switch high {
+	case r:
+		h = math.Mod((g-b)/d+6, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, l
+}
+
+// fromHSL returns the opaque color of the given hue, saturation and lightness, from 0 to 1
+func fromHSL(h float64, s float64, l float64) color.Color {
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h*6, 2)-1))
+	m := l - c/2
+	var r, g, b float64
+	switch int(h*6) % 6 {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	channel := func(v float64) uint8 {
+		return uint8(math.Round((v + m) * 0xff))
+	}
+	return color.RGBA{channel(r), channel(g), channel(b), 0xff}
+}
@@ -0,0 +1,55 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// GradientKind is the shape of a background gradient
+type GradientKind int
+
+const (
+	// Gradient from the top to the bottom of the canvas
+	LinearGradient GradientKind = iota
+	// Gradient from the center to the corners of the canvas
+	RadialGradient
+)
+
+// GradientStop is a color of a gradient, at an offset from 0, the start, to 1, the end
+type GradientStop struct {
+	Offset float64
+	Color  color.Color
+}
+
+// gradientImage paints the background gradient over the background color on a canvas of the given size
+func gradientImage(width int, height int, stops []GradientStop, kind GradientKind) image.Image {
+	dc := gg.NewContext(width, height)
+	var g gg.Gradient
+	switch kind {
+	case RadialGradient:
+		cx, cy := float64(width)/2, float64(height)/2
+		g = gg.NewRadialGradient(cx, cy, 0, cx, cy, math.Hypot(cx, cy))
+	default:
+		g = gg.NewLinearGradient(0, 0, 0, float64(height))
+	}
+	for _, s := range stops {
+		g.AddColorStop(s.Offset, s.Color)
+	}
+	dc.SetFillStyle(g)
+	dc.DrawRectangle(0, 0, float64(width), float64(height))
+	dc.Fill()
+	return dc.Image()
+}
+
+// clearBackground paints the background on the context, the gradient if any or the background color
+func (w *Wordcloud) clearBackground(dc *gg.Context) {
+	dc.SetColor(w.opts.BackgroundColor)
+	dc.Clear()
+	if w.background != nil {
+		draw.Draw(dc.Image().(draw.Image), w.background.Bounds(), w.background, image.Point{}, draw.Over)
+	}
+}
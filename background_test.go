@@ -0,0 +1,44 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_BackgroundGradient(t *testing.T) {
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(80),
+		Colors([]color.Color{color.RGBA{R: 0xff, A: 0xff}}),
+		BackgroundColor(color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}),
+		Width(400),
+		Height(400),
+	}
+	words := map[string]int{"gradient": 10, "stops": 5}
+	flat := NewWordcloud(words, options...)
+	flat.Draw()
+
+	for _, kind := range []GradientKind{LinearGradient, RadialGradient} {
+		w := NewWordcloud(words, append(options, BackgroundGradient([]GradientStop{
+			{0, color.White},
+			{1, color.Black},
+		}, kind))...)
+		img := w.Draw()
+
+		r, _, _, _ := img.At(2, 2).RGBA()
+		if kind == LinearGradient {
+			assert.Greater(t, r, uint32(0xf000))
+		} else {
+			assert.Less(t, r, uint32(0x1000))
+			r, _, _, _ = img.At(200, 200).RGBA()
+			assert.Greater(t, r, uint32(0xf000))
+		}
+		r, _, _, _ = img.At(2, 397).RGBA()
+		assert.Less(t, r, uint32(0x1000))
+
+		// The gradient is not mistaken for text
+		assert.Len(t, w.placed[0].boxes, len(flat.placed[0].boxes))
+	}
+}
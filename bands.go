@@ -0,0 +1,13 @@
+package wordclouds
+
+import "math"
+
+// bandY returns the center of the baseline band y falls in, or y without BaselineBands
+func (w *Wordcloud) bandY(y float64) float64 {
+	if w.opts.BaselineBands <= 0 {
+		return y
+	}
+	bandHeight := w.height / float64(w.opts.BaselineBands)
+	band := math.Max(0, math.Min(math.Floor(y/bandHeight), float64(w.opts.BaselineBands-1)))
+	return (band + 0.5) * bandHeight
+}
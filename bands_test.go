@@ -0,0 +1,29 @@
+package wordclouds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_BaselineBands(t *testing.T) {
+	for _, random := range []bool{false, true} {
+		w := newTestCloud(t, nil,
+			FontMaxSize(30),
+			FontMinSize(10),
+			RandomPlacement(random),
+			BaselineBands(8),
+		)
+		w.Draw()
+
+		assert.Greater(t, len(w.placed), 10)
+		rows := make(map[float64]int)
+		for _, pw := range w.placed {
+			band := (pw.y - 25) / 50
+			assert.Equal(t, math.Round(band), band, pw.word)
+			rows[pw.y]++
+		}
+		assert.Greater(t, len(rows), 4)
+	}
+}
@@ -0,0 +1,55 @@
+package wordclouds
+
+import (
+	"image/color"
+
+	"github.com/fogleman/gg"
+)
+
+// FrequencyBar is the style of the bars drawn on the right of the words, their length showing the count of the word
+type FrequencyBar struct {
+	// Length in pixels of the bar of the most frequent word
+	MaxLength float64
+	// Thickness of the bars and gap between a word and its bar, relative to the font size of the word in pixels
+	Thickness float64
+	Gap       float64
+	// Color of the bars. The bar of a word has the color of the word if nil.
+	Color color.Color
+}
+
+// barLength returns the length of the bar of the word, 0 without bars
+func (w *Wordcloud) barLength(wc wordCount) float64 {
+	if w.opts.Bar == nil || w.maxCount == 0 {
+		return 0
+	}
+	return w.opts.Bar.MaxLength * float64(wc.count) / float64(w.maxCount)
+}
+
+// barWidth returns the width taken by the bar of the word and its gap, 0 without a bar
+func (w *Wordcloud) barWidth(length float64, size float64) float64 {
+	if length <= 0 {
+		return 0
+	}
+	return length + w.opts.Bar.Gap*w.pixelSize(size)
+}
+
+// barRect returns the rectangle of the bar of the placed word, before rotation, with the center of the word at x, y
+func (w *Wordcloud) barRect(pw *placedWord, x float64, y float64) (left float64, top float64, width float64,
+	height float64) {
+	thickness := w.opts.Bar.Thickness * w.pixelSize(pw.size)
+	right := x + pw.width/2 - 2.5 - w.padding(pw.word)
+	return right - pw.bar, y - thickness/2, pw.bar, thickness
+}
+
+// drawBar draws the bar of the placed word centered on x, y. The word rotation must already be applied to dc.
+func (w *Wordcloud) drawBar(dc *gg.Context, pw *placedWord, x float64, y float64) {
+	if pw.bar <= 0 {
+		return
+	}
+	if w.opts.Bar.Color != nil {
+		dc.SetColor(w.opts.Bar.Color)
+	}
+	dc.DrawRectangle(w.barRect(pw, x, y))
+	dc.Fill()
+	dc.SetColor(pw.color)
+}
@@ -0,0 +1,46 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_BarStyle(t *testing.T) {
+	words := map[string]int{"often": 100, "rarely": 50}
+	bar := FrequencyBar{MaxLength: 100, Thickness: 0.3, Gap: 0.2, Color: color.RGBA{0, 0, 0xff, 0xff}}
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Colors([]color.Color{color.Black}),
+		BackgroundColor(color.White),
+		RandomPlacement(false),
+		FontMaxSize(60),
+		Width(600),
+		Height(400),
+	}
+	plain := NewWordcloud(words, options...)
+	w := NewWordcloud(words, append(options, BarStyle(bar))...)
+
+	// The bar length scales with the count
+	for word, length := range map[string]float64{"often": 100, "rarely": 50} {
+		plainWidth, _ := plain.MeasureWord(word, words[word])
+		width, _ := w.MeasureWord(word, words[word])
+		size := wordSize(&w.opts, words[word], w.maxCount, -1)
+		assert.InDelta(t, length+bar.Gap*size, width-plainWidth, 1)
+	}
+
+	img := w.Draw()
+	assert.Len(t, w.placed, 2)
+	for _, pw := range w.placed {
+		assert.InDelta(t, pw.width, pw.box.w(), 1e-9)
+
+		// The bar is drawn on the right of the word and is part of its boxes in the grid
+		x, y := pw.x+pw.width/2-2.5-pw.bar/2, pw.y
+		r, g, b, _ := img.At(int(x), int(y)).RGBA()
+		assert.Equal(t, []uint32{0, 0, 0xffff}, []uint32{r, g, b})
+		point := &Box{y, x, x, y}
+		a, _ := overlappingBoxes([]*Box{point}, pw.boxes)
+		assert.NotNil(t, a)
+	}
+}
@@ -0,0 +1,37 @@
+package wordclouds
+
+import (
+	"image"
+	"math"
+)
+
+// ContentBounds returns the smallest box holding every placed word, descenders included, within the canvas. It is
+// empty if no word was placed. Call it after Draw.
+func (w *Wordcloud) ContentBounds() Box {
+	if len(w.placed) == 0 {
+		return Box{}
+	}
+	b := Box{math.Inf(-1), math.Inf(1), math.Inf(-1), math.Inf(1)}
+	for _, pw := range w.placed {
+		b.Top = math.Max(b.Top, pw.bounds.Top)
+		b.Left = math.Min(b.Left, pw.bounds.Left)
+		b.Right = math.Max(b.Right, pw.bounds.Right)
+		b.Bottom = math.Min(b.Bottom, pw.bounds.Bottom)
+	}
+	return *b.clip(w.width, w.height)
+}
+
+// CropToContent draws the cloud if it has not been drawn yet and returns the image cropped to ContentBounds, trimming
+// the empty space around the words. The image is returned whole if its type cannot be cropped.
+func (w *Wordcloud) CropToContent() image.Image {
+	img := w.image()
+	b := w.ContentBounds()
+	rect := image.Rect(int(math.Floor(b.Left)), int(math.Floor(b.Bottom)), int(math.Ceil(b.Right)),
+		int(math.Ceil(b.Top)))
+	if sub, ok := img.(interface {
+		SubImage(r image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rect.Add(img.Bounds().Min))
+	}
+	return img
+}
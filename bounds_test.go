@@ -0,0 +1,45 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_ContentBounds(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"sparse": 10, "cloud": 8},
+		FontMaxSize(30),
+		Width(600),
+		Height(600),
+	)
+	assert.Equal(t, Box{}, w.ContentBounds())
+	img := w.CropToContent()
+	assert.Len(t, w.placed, 2)
+
+	b := w.ContentBounds()
+	for _, pw := range w.placed {
+		assert.True(t, b.Left <= pw.bounds.Left && b.Right >= pw.bounds.Right)
+		assert.True(t, b.Bottom <= pw.bounds.Bottom && b.Top >= pw.bounds.Top)
+	}
+	assert.True(t, b.w() < 300 && b.h() < 200)
+
+	// The cropped image holds every drawn pixel
+	expected := image.Rect(int(b.Left), int(b.Bottom), int(math.Ceil(b.Right)), int(math.Ceil(b.Top)))
+	assert.Equal(t, expected, img.Bounds())
+	full := w.output()
+	white := color.RGBAModel.Convert(color.White)
+	inked := 0
+	for x := 0; x < 600; x++ {
+		for y := 0; y < 600; y++ {
+			if color.RGBAModel.Convert(full.At(x, y)) == white {
+				continue
+			}
+			inked++
+			assert.True(t, image.Pt(x, y).In(img.Bounds()))
+		}
+	}
+	assert.Greater(t, inked, 0)
+}
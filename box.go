@@ -1,6 +1,9 @@
 package wordclouds
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 type Box struct {
 	Top    float64
@@ -25,8 +28,23 @@ func (a *Box) h() float64 {
 	return a.Top - a.Bottom
 }
 
-func (a *Box) fits(width float64, height float64) bool {
-	return a.Bottom > 0 && a.Top < height && a.Left > 0 && a.Right < width
+func (a *Box) area() float64 {
+	return a.w() * a.h()
+}
+
+// clip returns the part of the box inside the canvas
+func (a *Box) clip(width float64, height float64) *Box {
+	return &Box{
+		math.Max(math.Min(a.Top, height), 0),
+		math.Max(math.Min(a.Left, width), 0),
+		math.Max(math.Min(a.Right, width), 0),
+		math.Max(math.Min(a.Bottom, height), 0),
+	}
+}
+
+// fits tells if the box is inside the canvas, at least margin away from its edges
+func (a *Box) fits(width float64, height float64, margin float64) bool {
+	return a.Bottom > margin && a.Top < height-margin && a.Left > margin && a.Right < width-margin
 }
 func (a *Box) overlaps(b *Box) bool {
 	return a.Left <= b.Right && a.Right >= b.Left && a.Top >= b.Bottom && a.Bottom <= b.Top
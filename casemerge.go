@@ -0,0 +1,24 @@
+package wordclouds
+
+import "strings"
+
+// mergeCaseVariants returns the word list with the words differing only by case counted together. Each merged word
+// is written like its variant with the highest count, the first in lexical order on ties.
+func mergeCaseVariants(wordList map[string]int) map[string]int {
+	totals := make(map[string]int, len(wordList))
+	// Variant with the highest count for each lowercased word
+	forms := make(map[string]string, len(wordList))
+	for word, count := range wordList {
+		key := strings.ToLower(strings.Trim(word, " "))
+		totals[key] += count
+		form, ok := forms[key]
+		if !ok || count > wordList[form] || (count == wordList[form] && word < form) {
+			forms[key] = word
+		}
+	}
+	merged := make(map[string]int, len(totals))
+	for key, total := range totals {
+		merged[forms[key]] = total
+	}
+	return merged
+}
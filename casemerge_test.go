@@ -0,0 +1,26 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_MergeCaseVariants(t *testing.T) {
+	words := map[string]int{"iPhone": 7, "Iphone": 2, "iphone": 3, "Go": 4, "GO": 4, "cloud": 5}
+	w := NewWordcloud(words,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		MergeCaseVariants(true),
+	)
+
+	merged := map[string]int{}
+	for _, wc := range w.sortedWordList {
+		merged[wc.word] = wc.count
+	}
+	// Ties go to the first variant in lexical order
+	assert.Equal(t, map[string]int{"iPhone": 12, "GO": 8, "cloud": 5}, merged)
+	assert.Equal(t, "iPhone", w.sortedWordList[0].word)
+
+	w = NewWordcloud(words, FontFile("testdata/Roboto-Regular.ttf"))
+	assert.Len(t, w.sortedWordList, len(words))
+}
@@ -0,0 +1,65 @@
+package wordclouds
+
+import "math"
+
+// Step in pixels of the grid sampling the canvas to find the centroid of the area left free by masks
+const centroidStep = 10
+
+// center returns the centroid of the area where words can be placed, around the masks, the title and the watermark. It
+// is the center of the canvas without them.
+func (w *Wordcloud) center() (x float64, y float64) {
+	if len(w.opts.Mask) == 0 && w.opts.Title == "" && w.opts.Watermark.Text == "" {
+		return w.width / 2, w.height / 2
+	}
+
+	n := 0.0
+	var box Box
+	for i := centroidStep / 2; i < int(w.width); i += centroidStep {
+		for j := centroidStep / 2; j < int(w.height); j += centroidStep {
+			box = Box{float64(j), float64(i), float64(i), float64(j)}
+			colliding := w.collides(&box)
+			if !colliding {
+				x += float64(i)
+				y += float64(j)
+				n++
+			}
+		}
+	}
+	if n == 0 {
+		return w.width / 2, w.height / 2
+	}
+	return x / n, y / n
+}
+
+// placeCenterWord draws the CenterWord at the center of the free area before any other word. It returns the
+// remaining words, and the CenterWord if it did not fit there.
+func (w *Wordcloud) placeCenterWord(words []wordCount) (remaining []wordCount, skipped []wordCount) {
+	for i, wc := range words {
+		if wc.word != w.opts.CenterWord {
+			continue
+		}
+		remaining = append(append(make([]wordCount, 0, len(words)-1), words[:i]...), words[i+1:]...)
+		if w.track(wc, w.placeAtCenter) {
+			return remaining, nil
+		}
+		if w.opts.OnWordDropped != nil {
+			w.opts.OnWordDropped(wc.word, wc.count, wc.size)
+		}
+		return remaining, []wordCount{wc}
+	}
+	return words, nil
+}
+
+// placeAtCenter places the word at the center of the free area, if there is room for it there
+func (w *Wordcloud) placeAtCenter(wc wordCount) bool {
+	x, y := w.center()
+	return w.place(wc, func(width float64, height float64) (float64, float64, float64, bool) {
+		w.attempt.tries++
+		box := Box{y + height/2, x - width/2, x + width/2, y - height/2}
+		if !w.fits(w.withDescent(&box, 0)) || w.collides(&box) {
+			return 0, 0, 0, false
+		}
+		w.attempt.radius = math.Hypot(x-w.width/2, y-w.height/2)
+		return x, y, 0, true
+	})
+}
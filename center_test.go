@@ -0,0 +1,79 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_CenterWord(t *testing.T) {
+	telemetry := make(map[string]int)
+	w := NewWordcloud(map[string]int{"hub": 3, "spoke": 10, "rim": 5},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+		CenterWord("hub"),
+		Telemetry(func(word string, tries int, radius float64, dur time.Duration) {
+			telemetry[word] = tries
+		}),
+		RecordAttempts(true),
+	)
+	w.Draw()
+
+	assert.Equal(t, []string{"hub", "spoke", "rim"}, w.Result().Placed)
+	assert.Equal(t, 200.0, w.placed[0].x)
+	assert.Equal(t, 200.0, w.placed[0].y)
+	assert.Equal(t, 1, telemetry["hub"])
+	assert.Equal(t, PlacementAttempt{"hub", w.placed[0].size, 1, 0, true}, w.Attempts()[0])
+}
+
+func TestWordcloud_CenterWordSkipped(t *testing.T) {
+	var dropped []string
+	w := NewWordcloud(map[string]int{"hub": 3, "spoke": 10},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+		CenterWord("hub"),
+		// Only the borders of the canvas are free
+		MaskBoxes([]*Box{{300, 100, 300, 100}}),
+		OnWordDropped(func(word string, count int, attemptedSize float64) {
+			dropped = append(dropped, word)
+		}),
+	)
+	w.Draw()
+
+	assert.Equal(t, []string{"spoke"}, w.Result().Placed)
+	assert.Equal(t, []string{"hub"}, w.Result().Skipped)
+	assert.Equal(t, []string{"hub"}, dropped)
+}
+
+func TestWordcloud_CenterAboveWatermark(t *testing.T) {
+	w := NewWordcloud(map[string]int{"hub": 3},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Width(400),
+		Height(400),
+		Watermark("Watermark", BottomLeft, color.Black, 40),
+	)
+	// The free area is right of and above the watermark
+	x, y := w.center()
+	assert.Greater(t, x, 200.0)
+	assert.Less(t, y, 200.0)
+}
+
+func TestWordcloud_CenterBelowTitle(t *testing.T) {
+	w := NewWordcloud(map[string]int{"hub": 3},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Width(400),
+		Height(400),
+		Title("Title", TitleOptions{Size: 40}),
+	)
+	x, y := w.center()
+	assert.InDelta(t, 200, x, 5)
+	assert.InDelta(t, (400+w.titleBox().Top)/2, y, 5)
+}
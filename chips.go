@@ -0,0 +1,27 @@
+package wordclouds
+
+import (
+	"image/color"
+
+	"github.com/fogleman/gg"
+)
+
+// chipColor returns the color of the chip drawn behind the word, or nil if it has none
+func (w *Wordcloud) chipColor(word string) color.Color {
+	category, ok := w.opts.Categories[word]
+	if !ok {
+		return nil
+	}
+	return w.opts.CategoryColors[category]
+}
+
+// drawChip draws a rounded rectangle filling the box of the word, shifted by -dx, -dy. The word rotation must already
+// be applied to dc.
+func (w *Wordcloud) drawChip(dc *gg.Context, pw *placedWord, c color.Color, dx float64, dy float64) {
+	// The chip does not grow with WordPadding
+	padding := w.padding(pw.word)
+	width, height := pw.width-2*padding, pw.height-2*padding
+	dc.SetColor(c)
+	dc.DrawRoundedRectangle(pw.x-dx-width/2, pw.y-dy-height/2, width, height, height/4)
+	dc.Fill()
+}
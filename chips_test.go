@@ -0,0 +1,34 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_CategoryChips(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	w := NewWordcloud(map[string]int{"apple": 10, "sky": 8, "plain": 5},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		BackgroundColor(color.White),
+		Width(400),
+		Height(400),
+		CategoryChips(
+			map[string]string{"apple": "fruit", "sky": "nature"},
+			map[string]color.Color{"fruit": red, "nature": blue},
+		),
+	)
+	img := w.Draw()
+
+	expected := map[string]color.Color{"apple": red, "sky": blue, "plain": color.White}
+	assert.Len(t, w.placed, 3)
+	for _, pw := range w.placed {
+		// Left padding of the word, inside the chip but outside the letters
+		c := img.At(int(pw.box.Left)+1, int(pw.y))
+		assert.Equal(t, color.RGBAModel.Convert(expected[pw.word]), color.RGBAModel.Convert(c), pw.word)
+	}
+}
@@ -0,0 +1,75 @@
+package wordclouds
+
+import (
+	"image/color"
+	"math"
+)
+
+// Colormap maps a value between 0 and 1 to a color
+type Colormap func(t float64) color.Color
+
+// NewColormap returns a colormap interpolating linearly between evenly spaced color stops
+func NewColormap(stops ...color.Color) Colormap {
+	return func(t float64) color.Color {
+		if len(stops) == 0 {
+			return color.Black
+		}
+		if len(stops) == 1 {
+			return stops[0]
+		}
+		t = math.Max(0, math.Min(t, 1)) * float64(len(stops)-1)
+		i := int(math.Min(math.Floor(t), float64(len(stops)-2)))
+		return mixColors(stops[i], stops[i+1], t-float64(i))
+	}
+}
+
+// mixColors interpolates linearly from a to b, t being between 0 and 1
+func mixColors(a color.Color, b color.Color, t float64) color.Color {
+	na := color.NRGBAModel.Convert(a).(color.NRGBA)
+	nb := color.NRGBAModel.Convert(b).(color.NRGBA)
+	mix := func(x uint8, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + t*(float64(y)-float64(x))))
+	}
+	return color.NRGBA{mix(na.R, nb.R), mix(na.G, nb.G), mix(na.B, nb.B), mix(na.A, nb.A)}
+}
+
+// mixPremultiplied returns the color a fraction t of the way from a to b like mixColors, interpolating the channels
+// premultiplied by alpha. Mixing with a transparent color then only changes the opacity.
+func mixPremultiplied(a color.Color, b color.Color, t float64) color.Color {
+	ra := color.RGBA64Model.Convert(a).(color.RGBA64)
+	rb := color.RGBA64Model.Convert(b).(color.RGBA64)
+	mix := func(x uint16, y uint16) uint16 {
+		return uint16(math.Round(float64(x) + t*(float64(y)-float64(x))))
+	}
+	return color.RGBA64{mix(ra.R, rb.R), mix(ra.G, rb.G), mix(ra.B, rb.B), mix(ra.A, rb.A)}
+}
+
+var (
+	// Viridis goes from dark purple to yellow through blue and green
+	Viridis = NewColormap(
+		color.RGBA{0x44, 0x01, 0x54, 0xff},
+		color.RGBA{0x3b, 0x52, 0x8b, 0xff},
+		color.RGBA{0x21, 0x91, 0x8c, 0xff},
+		color.RGBA{0x5e, 0xc9, 0x62, 0xff},
+		color.RGBA{0xfd, 0xe7, 0x25, 0xff},
+	)
+	// BlueRed is a diverging colormap from blue to red through light gray, for values centered on a neutral one
+	BlueRed = NewColormap(
+		color.RGBA{0x21, 0x66, 0xac, 0xff},
+		color.RGBA{0xdd, 0xdd, 0xdd, 0xff},
+		color.RGBA{0xb2, 0x18, 0x2b, 0xff},
+	)
+)
+
+// valueColor returns the color of the value of the word through the colormap, or nil if the word has no value
+func (w *Wordcloud) valueColor(word string) color.Color {
+	v, ok := w.opts.WordValues[word]
+	if !ok || w.opts.ValueColormap == nil {
+		return nil
+	}
+	t := 0.5
+	if w.opts.ValueMax != w.opts.ValueMin {
+		t = (v - w.opts.ValueMin) / (w.opts.ValueMax - w.opts.ValueMin)
+	}
+	return w.opts.ValueColormap(t)
+}
@@ -0,0 +1,38 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// convertImage returns a copy of the image in the given color model. Models without a matching image type of the
+// standard library leave the image as is.
+func convertImage(img image.Image, model color.Model) image.Image {
+	b := img.Bounds()
+	var dst draw.Image
+	switch model {
+	case color.RGBAModel:
+		dst = image.NewRGBA(b)
+	case color.RGBA64Model:
+		dst = image.NewRGBA64(b)
+	case color.NRGBAModel:
+		dst = image.NewNRGBA(b)
+	case color.NRGBA64Model:
+		dst = image.NewNRGBA64(b)
+	case color.GrayModel:
+		dst = image.NewGray(b)
+	case color.Gray16Model:
+		dst = image.NewGray16(b)
+	case color.AlphaModel:
+		dst = image.NewAlpha(b)
+	case color.Alpha16Model:
+		dst = image.NewAlpha16(b)
+	case color.CMYKModel:
+		dst = image.NewCMYK(b)
+	default:
+		return img
+	}
+	draw.Draw(dst, b, img, b.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,124 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// withOpacity returns the color with its alpha multiplied by opacity, clamped to [0, 1]
+func withOpacity(c color.Color, opacity float64) color.Color {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = uint8(math.Round(float64(n.A) * math.Max(0, math.Min(opacity, 1))))
+	return n
+}
+
+// wordColor returns the color to draw the word with
+func (w *Wordcloud) wordColor(wc wordCount) color.Color {
+	c := w.wordColors[wc.word]
+	if c == nil {
+		c = w.valueColor(wc.word)
+	}
+	if c == nil {
+		c = w.pickColor(wc)
+	}
+	return w.wordOpacity(c, wc)
+}
+
+// wordOpacity returns the color with the opacity OpacityFunc gives to the word, if set
+func (w *Wordcloud) wordOpacity(c color.Color, wc wordCount) color.Color {
+	if w.opts.OpacityFunc == nil {
+		return c
+	}
+	return withOpacity(c, w.opts.OpacityFunc(wc.count, w.maxCount))
+}
+
+// fadeColor tints the color of a word at x, y toward the background, the more the farther from the center. Words in
+// the corners are tinted by EdgeFade.
+func (w *Wordcloud) fadeColor(c color.Color, x float64, y float64) color.Color {
+	distance := math.Hypot(x-w.width/2, y-w.height/2) / (math.Hypot(w.width, w.height) / 2)
+	if w.opts.PremultipliedAlpha {
+		return mixPremultiplied(c, w.opts.BackgroundColor, math.Min(w.opts.EdgeFade*distance, 1))
+	}
+	return mixColors(c, w.opts.BackgroundColor, math.Min(w.opts.EdgeFade*distance, 1))
+}
+
+// radiusColor returns the color of a word at x, y given by ColorByRadius, from its distance to the center of the
+// spiral
+func (w *Wordcloud) radiusColor(x float64, y float64) color.Color {
+	cx, cy := w.opts.spiralCenter()
+	maxRadius := math.Max(math.Hypot(cx, cy), math.Hypot(w.width-cx, w.height-cy))
+	maxRadius = math.Max(maxRadius, math.Max(math.Hypot(w.width-cx, cy), math.Hypot(cx, w.height-cy)))
+	if w.opts.MaxRadius > 0 {
+		maxRadius = math.Min(maxRadius, w.opts.MaxRadius)
+	}
+	return w.opts.ColorByRadius(math.Hypot(x-cx, y-cy), maxRadius)
+}
+
+// Contrast ratio AutoContrast ensures between the words and the background, the one advised for large text
+const minContrast = 3
+
+// luminance returns the relative luminance of the color, from 0 for black to 1 for white
+func luminance(c color.Color) float64 {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	linear := func(v uint8) float64 {
+		s := float64(v) / 0xff
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(n.R) + 0.7152*linear(n.G) + 0.0722*linear(n.B)
+}
+
+// contrast returns the contrast ratio of two colors, from 1 for the same luminance to 21 for black and white
+func contrast(a color.Color, b color.Color) float64 {
+	la, lb := luminance(a), luminance(b)
+	return (math.Max(la, lb) + 0.05) / (math.Min(la, lb) + 0.05)
+}
+
+// canvasColor returns the average color of the canvas in the box, sampled on a few points
+func (w *Wordcloud) canvasColor(b *Box) color.Color {
+	const samples = 5
+	img := w.dc.Image()
+	var r, g, bl, n uint32
+	for i := 0; i < samples; i++ {
+		for j := 0; j < samples; j++ {
+			x := int(b.Left + (b.Right-b.Left)*(float64(i)+0.5)/samples)
+			y := int(b.Bottom + (b.Top-b.Bottom)*(float64(j)+0.5)/samples)
+			if !(image.Point{x, y}.In(img.Bounds())) {
+				continue
+			}
+			pr, pg, pb, _ := img.At(x, y).RGBA()
+			r, g, bl, n = r+pr>>8, g+pg>>8, bl+pb>>8, n+1
+		}
+	}
+	if n == 0 {
+		return w.opts.BackgroundColor
+	}
+	return color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), 0xff}
+}
+
+// contrastColor darkens or lightens the color of a word until it contrasts enough with the background under the box
+func (w *Wordcloud) contrastColor(c color.Color, b *Box) color.Color {
+	return withContrast(c, w.canvasColor(b))
+}
+
+// withContrast darkens or lightens the color until it contrasts enough with the background color
+func withContrast(c color.Color, bg color.Color) color.Color {
+	if contrast(c, bg) >= minContrast {
+		return c
+	}
+	// Move toward black on light backgrounds and toward white on dark ones
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	target := color.NRGBA{A: n.A}
+	if contrast(color.White, bg) > contrast(color.Black, bg) {
+		target = color.NRGBA{0xff, 0xff, 0xff, n.A}
+	}
+	for t := 0.1; t < 1; t += 0.1 {
+		if m := mixColors(n, target, t); contrast(m, bg) >= minContrast {
+			return m
+		}
+	}
+	return target
+}
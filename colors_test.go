@@ -0,0 +1,272 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_OpacityFunc(t *testing.T) {
+	w := NewWordcloud(map[string]int{"frequent": 10, "rare": 5},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.RGBA{R: 0xff, A: 0xff}}),
+		Width(400),
+		Height(400),
+		OpacityFunc(func(count int, maxCount int) float64 {
+			return float64(count) / float64(maxCount)
+		}),
+	)
+	w.Draw()
+
+	placed := w.PlacedWords()
+	assert.Len(t, placed, 2)
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, placed[0].Color)
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0x80}, placed[1].Color)
+}
+
+func TestWordcloud_WordValues(t *testing.T) {
+	w := NewWordcloud(map[string]int{"good": 10, "bad": 8, "neutral": 5, "unknown": 3},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+		WordValues(map[string]float64{"good": 1, "bad": -1, "neutral": 0}, -1, 1, BlueRed),
+	)
+	w.Draw()
+
+	colors := make(map[string]color.Color)
+	for _, pw := range w.PlacedWords() {
+		colors[pw.Word] = pw.Color
+	}
+	assert.Equal(t, color.NRGBA{0xb2, 0x18, 0x2b, 0xff}, colors["good"])
+	assert.Equal(t, color.NRGBA{0x21, 0x66, 0xac, 0xff}, colors["bad"])
+	assert.Equal(t, color.NRGBA{0xdd, 0xdd, 0xdd, 0xff}, colors["neutral"])
+	assert.Equal(t, color.Black, colors["unknown"])
+}
+
+func TestWordcloud_EdgeFade(t *testing.T) {
+	w := NewWordcloud(loadTestWords(t),
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		FontMinSize(10),
+		Colors([]color.Color{color.Black}),
+		BackgroundColor(color.White),
+		Width(400),
+		Height(400),
+		EdgeFade(1),
+	)
+	w.Draw()
+
+	// The word farthest from the center
+	far := w.placed[0]
+	for _, pw := range w.placed {
+		if math.Hypot(pw.x-200, pw.y-200) > math.Hypot(far.x-200, far.y-200) {
+			far = pw
+		}
+	}
+	distance := math.Hypot(far.x-200, far.y-200) / math.Hypot(200, 200)
+	assert.Greater(t, distance, 0.5)
+	level := uint8(math.Round(0xff * distance))
+	assert.Equal(t, color.NRGBA{level, level, level, 0xff}, far.color)
+}
+
+func TestWordcloud_ColorByRadius(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	w := NewWordcloud(loadTestWords(t),
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		FontMinSize(10),
+		Width(400),
+		Height(400),
+		ColorByRadius(func(radius float64, maxRadius float64) color.Color {
+			switch {
+			case radius < maxRadius/4:
+				return red
+			case radius < maxRadius/2:
+				return green
+			default:
+				return blue
+			}
+		}),
+	)
+	w.Draw()
+
+	// The first word is at the center, the last ones near the edges
+	assert.Equal(t, red, w.placed[0].color)
+	bands := make(map[color.Color]int)
+	for _, pw := range w.placed {
+		distance := math.Hypot(pw.x-200, pw.y-200) / math.Hypot(200, 200)
+		if distance > 0.6 {
+			assert.Equal(t, blue, pw.color, pw.word)
+		} else if distance < 0.2 {
+			assert.Equal(t, red, pw.color, pw.word)
+		}
+		bands[pw.color]++
+	}
+	assert.Len(t, bands, 3)
+}
+
+func TestWordcloud_ColorByRadiusOpacity(t *testing.T) {
+	w := NewWordcloud(map[string]int{"opaque": 10, "faint": 5},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Width(400),
+		Height(400),
+		ColorByRadius(func(radius float64, maxRadius float64) color.Color {
+			return color.RGBA{R: 0xff, A: 0xff}
+		}),
+		OpacityFunc(func(count int, maxCount int) float64 {
+			return float64(count) / float64(maxCount)
+		}),
+	)
+	w.Draw()
+
+	assert.Len(t, w.placed, 2)
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0xff}, w.placed[0].color)
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0x80}, w.placed[1].color)
+}
+
+func TestWordcloud_AutoContrast(t *testing.T) {
+	for _, bg := range []color.Color{color.RGBA{0xf0, 0xf0, 0xf0, 0xff}, color.RGBA{0x20, 0x10, 0x10, 0xff}} {
+		faint := mixColors(bg, color.Gray{0x80}, 0.1)
+		w := NewWordcloud(map[string]int{"faint": 10, "words": 5},
+			FontFile("testdata/Roboto-Regular.ttf"),
+			FontMaxSize(40),
+			BackgroundColor(bg),
+			Colors([]color.Color{faint}),
+			Width(400),
+			Height(400),
+			AutoContrast(true),
+		)
+		w.Draw()
+
+		assert.Len(t, w.placed, 2)
+		for _, pw := range w.placed {
+			assert.NotEqual(t, faint, pw.color)
+			assert.GreaterOrEqual(t, contrast(pw.color, bg), float64(minContrast), pw.word)
+		}
+		// Colors with enough contrast are kept
+		corner := &Box{20, 0, 20, 0}
+		var kept color.Color = color.White
+		if luminance(bg) > 0.5 {
+			kept = color.Black
+		}
+		assert.Equal(t, kept, w.contrastColor(kept, corner))
+	}
+}
+
+func TestWordcloud_ColorCycle(t *testing.T) {
+	palette := []color.Color{
+		color.RGBA{R: 0xff, A: 0xff},
+		color.RGBA{G: 0xff, A: 0xff},
+		color.RGBA{B: 0xff, A: 0xff},
+	}
+	w := NewWordcloud(loadTestWords(t),
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		FontMinSize(10),
+		Colors(palette),
+		Width(400),
+		Height(400),
+		ColorCycle(true),
+	)
+	w.Draw()
+
+	assert.Greater(t, len(w.placed), 6)
+	for i, pw := range w.placed {
+		assert.Equal(t, palette[i%3], pw.color, pw.word)
+	}
+}
+
+func TestWordcloud_PremultipliedAlpha(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	composite := func(premultiplied bool) (image.Image, color.Color) {
+		w := NewWordcloud(loadTestWords(t),
+			FontFile("testdata/Roboto-Regular.ttf"),
+			FontMaxSize(40),
+			FontMinSize(10),
+			Colors([]color.Color{red}),
+			BackgroundColor(color.Transparent),
+			Width(400),
+			Height(400),
+			EdgeFade(2),
+			PremultipliedAlpha(premultiplied),
+		)
+		img := w.Draw()
+		_, isRGBA := img.(*image.RGBA)
+		assert.True(t, isRGBA)
+
+		// A word half faded away, over black
+		for _, pw := range w.placed {
+			if _, _, _, a := pw.color.RGBA(); a > 0x7000 && a < 0x9000 {
+				dst := image.NewRGBA(image.Rect(0, 0, 1, 1))
+				draw.Draw(dst, dst.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+				draw.Draw(dst, dst.Bounds(), image.NewUniform(pw.color), image.Point{}, draw.Over)
+				return img, dst.At(0, 0)
+			}
+		}
+		t.Fatal("no half faded word")
+		return nil, nil
+	}
+
+	// Half of the red is left over black, only the opacity is faded
+	img, c := composite(true)
+	r, g, b, _ := c.RGBA()
+	_, _, _, a := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0), a)
+	assert.InDelta(t, 0x8000, r, 0x1000)
+	assert.Equal(t, uint32(0), g)
+	assert.Equal(t, uint32(0), b)
+
+	// Mixing the straight colors also darkens the red
+	_, c = composite(false)
+	r, _, _, _ = c.RGBA()
+	assert.InDelta(t, 0x4000, r, 0x1000)
+
+	// The output stays premultiplied after a conversion
+	w := NewWordcloud(map[string]int{"red": 1},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Width(100),
+		Height(100),
+		ColorModel(color.NRGBAModel),
+		PremultipliedAlpha(true),
+	)
+	_, isRGBA := w.Draw().(*image.RGBA)
+	assert.True(t, isRGBA)
+}
+
+func TestAutoPalette(t *testing.T) {
+	backgrounds := []color.Color{
+		color.White,
+		color.Black,
+		color.RGBA{0x30, 0x60, 0xa0, 0xff},
+		color.RGBA{0xf0, 0xe0, 0x40, 0xff},
+	}
+	for _, bg := range backgrounds {
+		w := NewWordcloud(map[string]int{"a": 1},
+			FontFile("testdata/Roboto-Regular.ttf"),
+			BackgroundColor(bg),
+			AutoPalette(6),
+		)
+		assert.Len(t, w.opts.Colors, 6)
+		for _, c := range w.opts.Colors {
+			assert.True(t, contrast(c, bg) >= minContrast)
+		}
+	}
+
+	// Given colors are kept
+	w := NewWordcloud(map[string]int{"a": 1},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Colors([]color.Color{color.Black}),
+		AutoPalette(6),
+	)
+	assert.Equal(t, []color.Color{color.Black}, w.opts.Colors)
+}
@@ -0,0 +1,103 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// CombineDirection tells Combine how to arrange the clouds
+type CombineDirection int
+
+const (
+	// CombineHorizontal places the clouds side by side, left to right
+	CombineHorizontal CombineDirection = iota
+	// CombineVertical stacks the clouds top to bottom
+	CombineVertical
+)
+
+// CombineLayout describes how Combine arranges several clouds in a single image.
+type CombineLayout struct {
+	Direction CombineDirection
+	// Gap in pixels between two consecutive clouds
+	Gap int
+	// Background of the combined image, visible in the gaps and around smaller clouds.
+	// Defaults to the background color of the first cloud.
+	Background color.Color
+}
+
+// DrawTo draws the wordcloud if it has not been drawn yet and renders it onto dst, with the top left corner of the
+// cloud at offset.
+func (w *Wordcloud) DrawTo(dst draw.Image, offset image.Point) {
+	img := w.image()
+	r := img.Bounds().Sub(img.Bounds().Min).Add(offset)
+	draw.Draw(dst, r, img, img.Bounds().Min, draw.Over)
+}
+
+// OccupiedBoxes returns the boxes covering the words drawn by Draw, translated by offset. Passed as the Mask of a
+// cloud drawn after this one on the same image, they keep its words away from the words of this cloud. offset is the
+// position of this cloud minus the position of the other one.
+func (w *Wordcloud) OccupiedBoxes(offset image.Point) []*Box {
+	boxes := w.grid.Boxes(false)
+	res := make([]*Box, 0, len(boxes))
+	for _, b := range boxes {
+		res = append(res, &Box{
+			b.Top + float64(offset.Y),
+			b.Left + float64(offset.X),
+			b.Right + float64(offset.X),
+			b.Bottom + float64(offset.Y),
+		})
+	}
+	return res
+}
+
+// Combine draws the clouds and renders them into one image, either side by side or stacked.
+// Clouds with differing canvas sizes are not scaled: the combined image is as tall (horizontal layout) or as wide
+// (vertical layout) as the largest cloud, and smaller clouds are centered on that axis.
+func Combine(clouds []*Wordcloud, layout CombineLayout) image.Image {
+	if len(clouds) == 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	// Main axis is the sum of the sizes plus gaps, cross axis is the largest size
+	main, cross := 0, 0
+	for i, c := range clouds {
+		m, x := int(c.width), int(c.height)
+		if layout.Direction == CombineVertical {
+			m, x = x, m
+		}
+		if i > 0 {
+			main += layout.Gap
+		}
+		main += m
+		if x > cross {
+			cross = x
+		}
+	}
+
+	bounds := image.Rect(0, 0, main, cross)
+	if layout.Direction == CombineVertical {
+		bounds = image.Rect(0, 0, cross, main)
+	}
+
+	bg := layout.Background
+	if bg == nil {
+		bg = clouds[0].opts.BackgroundColor
+	}
+	dst := image.NewRGBA(bounds)
+	draw.Draw(dst, bounds, image.NewUniform(bg), image.Point{}, draw.Src)
+
+	pos := 0
+	for _, c := range clouds {
+		var offset image.Point
+		if layout.Direction == CombineVertical {
+			offset = image.Pt((cross-int(c.width))/2, pos)
+			pos += int(c.height) + layout.Gap
+		} else {
+			offset = image.Pt(pos, (cross-int(c.height))/2)
+			pos += int(c.width) + layout.Gap
+		}
+		c.DrawTo(dst, offset)
+	}
+	return dst
+}
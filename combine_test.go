@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_DrawTo(t *testing.T) {
+	w := NewWordcloud(map[string]int{"combine": 10, "draw": 5},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		Width(200),
+		Height(100),
+	)
+	drawn := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	draw.Draw(drawn, drawn.Bounds(), w.Draw(), image.Point{}, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	w.DrawTo(dst, image.Pt(200, 0))
+	// The words drawn already are not drawn again
+	assert.Equal(t, drawn, w.dc.Image())
+	assert.Equal(t, w.dc.Image().At(100, 50), dst.At(300, 50))
+}
+
+func TestWordcloud_OccupiedBoxes(t *testing.T) {
+	first := newTestCloud(t, map[string]int{"hello": 10, "world": 5},
+		FontMaxSize(60),
+	)
+	first.Draw()
+
+	// The second cloud is drawn 100 pixels right of the first one
+	mask := first.OccupiedBoxes(image.Pt(-100, 0))
+	assert.NotEmpty(t, mask)
+	second := newTestCloud(t, map[string]int{"foo": 10, "bar": 5},
+		FontMaxSize(60),
+		MaskBoxes(mask),
+	)
+	second.Draw()
+
+	assert.Len(t, second.placed, 2)
+	for _, pw := range second.placed {
+		for _, b := range mask {
+			assert.False(t, pw.box.overlaps(b), pw.word)
+		}
+	}
+}
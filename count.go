@@ -0,0 +1,176 @@
+package wordclouds
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type countOptions struct {
+	splitCompounds bool
+	normalizer     func(string) string
+	synonyms       map[string]string
+}
+
+// CountOption configures how CountWords splits and counts words
+type CountOption func(*countOptions)
+
+// Split words joined by hyphens or underscores, "state-of-the-art" being counted as "state", "of", "the" and "art".
+// They are kept joined by default.
+func SplitCompounds(do bool) CountOption {
+	return func(options *countOptions) {
+		options.splitCompounds = do
+	}
+}
+
+// Normalize each word before counting it, e.g. to stem "running" into "run". Words normalized to the same form
+// are counted together, and words normalized to an empty string are dropped.
+func Normalizer(f func(string) string) CountOption {
+	return func(options *countOptions) {
+		options.normalizer = f
+	}
+}
+
+// Count the variants of a word under one canonical form, e.g. "US", "U.S." and "USA" as "United States". Variants
+// are matched as whole words, case sensitively, before the text is split into words, so they may contain spaces
+// or punctuation. The canonical forms are counted as is.
+func Synonyms(synonyms map[string]string) CountOption {
+	return func(options *countOptions) {
+		options.synonyms = synonyms
+	}
+}
+
+// CountWords splits a text into words and counts their occurrences. The result can be used as the word list of
+// NewWordcloud.
+func CountWords(text string, options ...CountOption) map[string]int {
+	opts := countOptions{
+		normalizer: func(s string) string { return s },
+	}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	counts := make(map[string]int)
+	text, canonical := extractSynonyms(text, opts)
+	for _, c := range canonical {
+		counts[c]++
+	}
+	opts.countTokens(counts, tokenize(text, opts))
+	return counts
+}
+
+// countTokens adds the occurrences of the tokens to counts, each normalized and dropped if it is normalized to an
+// empty string
+func (opts countOptions) countTokens(counts map[string]int, tokens []string) {
+	for _, token := range tokens {
+		token = opts.normalizer(token)
+		if token == "" {
+			continue
+		}
+		counts[token]++
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isJoiner tells whether the rune joins the word runes around it into one word
+func (opts countOptions) isJoiner(r rune) bool {
+	return r == '\'' || (!opts.splitCompounds && (r == '-' || r == '_'))
+}
+
+// tokenize returns the words of the text. Words are made of letters and digits, possibly joined by apostrophes and,
+// unless compounds are split, hyphens and underscores.
+func tokenize(text string, opts countOptions) []string {
+	isJoiner := opts.isJoiner
+	tokens := make([]string, 0)
+	runes := []rune(text)
+	start := -1
+	for i, r := range runes {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && isJoiner(r) && i+1 < len(runes) && isWordRune(runes[i+1]) {
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, string(runes[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, string(runes[start:]))
+	}
+	return tokens
+}
+
+// extractSynonyms finds the variants of the synonyms in the text, the longest first. It returns the text with the
+// variants blanked out and the canonical forms of the variants found.
+func extractSynonyms(text string, opts countOptions) (string, []string) {
+	synonyms := opts.synonyms
+	if len(synonyms) == 0 {
+		return text, nil
+	}
+	variants := make([]string, 0, len(synonyms))
+	for v := range synonyms {
+		if v != "" {
+			variants = append(variants, v)
+		}
+	}
+	sort.Slice(variants, func(i, j int) bool {
+		if len(variants[i]) == len(variants[j]) {
+			return variants[i] < variants[j]
+		}
+		return len(variants[i]) > len(variants[j])
+	})
+
+	canonical := make([]string, 0)
+	for _, v := range variants {
+		from := 0
+		for {
+			i := strings.Index(text[from:], v)
+			if i < 0 {
+				break
+			}
+			i += from
+			end := i + len(v)
+			if opts.continuesWord(text[:i], true) || opts.continuesWord(text[end:], false) {
+				from = i + 1
+				continue
+			}
+			canonical = append(canonical, synonyms[v])
+			text = text[:i] + strings.Repeat(" ", len(v)) + text[end:]
+			from = end
+		}
+	}
+	return text, canonical
+}
+
+// continuesWord tells whether the text next to a match, before it if backwards is set, is part of the same word
+func (opts countOptions) continuesWord(text string, backwards bool) bool {
+	next := func(s string) (rune, string) {
+		if backwards {
+			r, n := utf8.DecodeLastRuneInString(s)
+			return r, s[:len(s)-n]
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		return r, s[n:]
+	}
+	if text == "" {
+		return false
+	}
+	r, rest := next(text)
+	if isWordRune(r) {
+		return true
+	}
+	if !opts.isJoiner(r) || rest == "" {
+		return false
+	}
+	r, _ = next(rest)
+	return isWordRune(r)
+}
@@ -0,0 +1,84 @@
+package wordclouds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountWords_SplitCompounds(t *testing.T) {
+	text := "A state-of-the-art parser, state_of_the_art again - and the end-"
+
+	assert.Equal(t, map[string]int{
+		"A":                1,
+		"state-of-the-art": 1,
+		"parser":           1,
+		"state_of_the_art": 1,
+		"again":            1,
+		"and":              1,
+		"the":              1,
+		"end":              1,
+	}, CountWords(text))
+
+	assert.Equal(t, map[string]int{
+		"A":      1,
+		"state":  2,
+		"of":     2,
+		"the":    3,
+		"art":    2,
+		"parser": 1,
+		"again":  1,
+		"and":    1,
+		"end":    1,
+	}, CountWords(text, SplitCompounds(true)))
+}
+
+func TestCountWords_Normalizer(t *testing.T) {
+	stem := func(s string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(s), "ning"), "s")
+	}
+
+	assert.Equal(t, map[string]int{"run": 3, "fast": 1}, CountWords("Running runs run fast", Normalizer(stem)))
+}
+
+func TestCountWords_Synonyms(t *testing.T) {
+	text := "The US, the U.S. and the USA are big. USAF and US-based are other words."
+	synonyms := map[string]string{
+		"US":   "United States",
+		"U.S.": "United States",
+		"USA":  "United States",
+	}
+
+	counts := CountWords(text, Synonyms(synonyms))
+	assert.Equal(t, 3, counts["United States"])
+	assert.Equal(t, 1, counts["USAF"])
+	assert.Equal(t, 1, counts["US-based"])
+	assert.NotContains(t, counts, "US")
+	assert.NotContains(t, counts, "USA")
+	assert.NotContains(t, counts, "U")
+}
+
+func TestCountTFIDF(t *testing.T) {
+	documents := []string{
+		"the cat sat on the mat with the cat",
+		"the dog ate the bone",
+		"the bird sang and the bird flew",
+	}
+
+	weights := CountTFIDF(documents)
+	assert.Len(t, weights, 13)
+	// "the" is the most frequent word but is found in every document
+	assert.Equal(t, 1000, weights["bird"])
+	assert.Less(t, weights["the"], weights["cat"])
+	assert.Less(t, weights["the"], weights["bird"])
+	assert.Equal(t, 1, weights["the"])
+
+	// Count options are applied to every document
+	weights = CountTFIDF([]string{"Cat", "cat dog"}, Normalizer(strings.ToLower))
+	assert.Equal(t, 1, weights["cat"])
+	assert.Equal(t, 1000, weights["dog"])
+	assert.Len(t, weights, 2)
+	assert.Contains(t, weights, "cat")
+	assert.Contains(t, weights, "dog")
+}
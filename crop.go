@@ -0,0 +1,41 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// circleCrop returns a copy of the image keeping only the largest circle centered on it. The pixels outside the
+// circle are set to the outside color, and the pixels on its edge are blended with it for a smooth edge.
+func circleCrop(img image.Image, outside color.Color) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, img, b.Min, draw.Src)
+
+	cx, cy := float64(b.Min.X+b.Max.X)/2, float64(b.Min.Y+b.Max.Y)/2
+	radius := math.Min(float64(b.Dx()), float64(b.Dy())) / 2
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			// Coverage of the pixel by the circle, from its center
+			d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			coverage := math.Max(0, math.Min(radius-d+0.5, 1))
+			if coverage == 1 {
+				continue
+			}
+			dst.Set(x, y, mixPremultiplied(outside, dst.At(x, y), coverage))
+		}
+	}
+	return dst
+}
+
+// cropOutside returns the color of the pixels cropped out of the image: transparent, unless the color model of the
+// output has no alpha and they keep the background color
+func (w *Wordcloud) cropOutside() color.Color {
+	switch w.opts.ColorModel {
+	case color.GrayModel, color.Gray16Model, color.CMYKModel:
+		return w.opts.BackgroundColor
+	}
+	return color.Transparent
+}
@@ -0,0 +1,46 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_CircleCrop(t *testing.T) {
+	words := map[string]int{"round": 10, "avatar": 5}
+	draw := func(options ...Option) image.Image {
+		w := NewWordcloud(words, append([]Option{
+			FontFile("testdata/Roboto-Regular.ttf"),
+			FontMaxSize(40),
+			Colors([]color.Color{color.Black}),
+			BackgroundColor(color.RGBA{0xff, 0, 0, 0xff}),
+			Width(200),
+			Height(100),
+		}, options...)...)
+		return w.Draw()
+	}
+	full := draw()
+	img := draw(CircleCrop(true))
+
+	// The corners are cut, the inside of the circle is kept
+	for _, corner := range []image.Point{{0, 0}, {199, 0}, {0, 99}, {199, 99}, {49, 50}, {150, 50}} {
+		assert.Equal(t, color.RGBAModel.Convert(color.Transparent), color.RGBAModel.Convert(img.At(corner.X, corner.Y)))
+	}
+	for _, inside := range []image.Point{{100, 50}, {100, 1}, {51, 50}, {148, 50}, {120, 70}} {
+		assert.Equal(t, color.RGBAModel.Convert(full.At(inside.X, inside.Y)),
+			color.RGBAModel.Convert(img.At(inside.X, inside.Y)))
+	}
+
+	// Translucent backgrounds are kept inside the circle
+	img = draw(CircleCrop(true), BackgroundColor(color.RGBA{0, 0, 0x80, 0x80}))
+	assert.Equal(t, color.RGBA{0, 0, 0x80, 0x80}, color.RGBAModel.Convert(img.At(100, 98)))
+	assert.Equal(t, color.RGBAModel.Convert(color.Transparent), color.RGBAModel.Convert(img.At(0, 0)))
+
+	// Quantized images get a transparent color, models without alpha keep the background outside
+	img = draw(CircleCrop(true), QuantizePalette(true))
+	assert.Equal(t, color.RGBAModel.Convert(color.Transparent), color.RGBAModel.Convert(img.At(0, 0)))
+	img = draw(CircleCrop(true), ColorModel(color.GrayModel))
+	assert.Equal(t, color.GrayModel.Convert(color.RGBA{0xff, 0, 0, 0xff}), img.At(0, 0))
+}
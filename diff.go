@@ -0,0 +1,58 @@
+package wordclouds
+
+import "image/color"
+
+// DiffColors are the colors of the words of a diff cloud, by direction of their count change
+type DiffColors struct {
+	// Words counted more often after than before
+	Grew color.Color
+	// Words counted less often after than before, or not anymore
+	Shrank color.Color
+	// Words only counted after
+	New color.Color
+}
+
+// DefaultDiffColors draws words that grew in green, shrank in red and new ones in blue
+var DefaultDiffColors = DiffColors{
+	Grew:   color.RGBA{0x2e, 0x9e, 0x44, 0xff},
+	Shrank: color.RGBA{0xd6, 0x28, 0x28, 0xff},
+	New:    color.RGBA{0x1f, 0x6f, 0xd1, 0xff},
+}
+
+// NewDiffWordcloud initializes a wordcloud comparing two maps of word frequency. Words are sized by how much their
+// count changed and colored by the direction of the change. Unchanged words are left out.
+func NewDiffWordcloud(before map[string]int, after map[string]int, colors DiffColors, options ...Option) *Wordcloud {
+	changes := make(map[string]int)
+	wordColors := make(map[string]color.Color)
+	for word, count := range after {
+		previous, ok := before[word]
+		switch {
+		case !ok:
+			wordColors[word] = colors.New
+		case count > previous:
+			wordColors[word] = colors.Grew
+		case count < previous:
+			wordColors[word] = colors.Shrank
+		default:
+			continue
+		}
+		changes[word] = abs(count - previous)
+	}
+	for word, count := range before {
+		if _, ok := after[word]; !ok && count != 0 {
+			changes[word] = abs(count)
+			wordColors[word] = colors.Shrank
+		}
+	}
+
+	w := NewWordcloud(changes, options...)
+	w.wordColors = wordColors
+	return w
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
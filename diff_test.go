@@ -0,0 +1,33 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiffWordcloud(t *testing.T) {
+	before := map[string]int{"grew": 2, "shrank": 10, "same": 4, "gone": 3}
+	after := map[string]int{"grew": 8, "shrank": 7, "same": 4, "new": 5}
+	w := NewDiffWordcloud(before, after, DefaultDiffColors,
+		FontMaxSize(40),
+		Width(400),
+		Height(400),
+	)
+	w.Draw()
+
+	counts := make(map[string]int)
+	colors := make(map[string]color.Color)
+	for _, pw := range w.PlacedWords() {
+		counts[pw.Word] = pw.Count
+		colors[pw.Word] = pw.Color
+	}
+	assert.Equal(t, map[string]int{"grew": 6, "shrank": 3, "gone": 3, "new": 5}, counts)
+	assert.Equal(t, map[string]color.Color{
+		"grew":   DefaultDiffColors.Grew,
+		"shrank": DefaultDiffColors.Shrank,
+		"gone":   DefaultDiffColors.Shrank,
+		"new":    DefaultDiffColors.New,
+	}, colors)
+}
@@ -0,0 +1,95 @@
+package wordclouds
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// image returns the drawn cloud, drawing it first if needed
+func (w *Wordcloud) image() image.Image {
+	if !w.drawn {
+		return w.Draw()
+	}
+	return w.output()
+}
+
+// pngBuffers keeps the buffers of the png encoder of a cloud from one encoding to the next
+type pngBuffers struct {
+	b *png.EncoderBuffer
+}
+
+func (p *pngBuffers) Get() *png.EncoderBuffer {
+	return p.b
+}
+
+func (p *pngBuffers) Put(b *png.EncoderBuffer) {
+	p.b = b
+}
+
+// DrawPNG draws the cloud if it has not been drawn yet and encodes it as png to out. The encoder buffers are kept for
+// the next call, e.g. after Reset, sparing their allocation when encoding many clouds.
+func (w *Wordcloud) DrawPNG(out io.Writer) error {
+	return w.Encode(out, "png", 0)
+}
+
+// Encode draws the cloud if it has not been drawn yet and encodes it to out. The format is one of "png", "jpeg"
+// (or "jpg") and "gif". Quality only applies to jpeg, from 1 to 100.
+func (w *Wordcloud) Encode(out io.Writer, format string, quality int) error {
+	switch format {
+	case "png":
+		img := w.image()
+		out, err := w.pngWriter(out)
+		if err != nil {
+			return err
+		}
+		enc := png.Encoder{BufferPool: &w.pngBuffers}
+		return enc.Encode(out, img)
+	case "jpeg", "jpg":
+		return jpeg.Encode(out, w.image(), &jpeg.Options{Quality: quality})
+	case "gif":
+		return gif.Encode(out, w.image(), nil)
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// EncodeJPEGToSize draws the cloud if it has not been drawn yet and encodes it as jpeg with the highest quality
+// keeping the output under maxBytes. If even the lowest quality is too large, the lowest quality is used.
+// Returns the encoded image and the chosen quality.
+func (w *Wordcloud) EncodeJPEGToSize(maxBytes int) ([]byte, int, error) {
+	img := w.image()
+	encode := func(quality int) ([]byte, error) {
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		return buf.Bytes(), err
+	}
+
+	// Binary search of the highest quality that fits, the size growing with the quality
+	var best []byte
+	bestQuality := 0
+	low, high := 1, 100
+	for low <= high {
+		quality := (low + high) / 2
+		data, err := encode(quality)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(data) <= maxBytes {
+			best, bestQuality = data, quality
+			low = quality + 1
+		} else {
+			high = quality - 1
+		}
+	}
+
+	if best == nil {
+		data, err := encode(1)
+		return data, 1, err
+	}
+	return best, bestQuality, nil
+}
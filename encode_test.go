@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_EncodeJPEGToSize(t *testing.T) {
+	w := newTestCloud(t, nil)
+	var full bytes.Buffer
+	assert.NoError(t, w.Encode(&full, "jpeg", 100))
+
+	maxBytes := full.Len() / 2
+	data, quality, err := w.EncodeJPEGToSize(maxBytes)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxBytes)
+	assert.Less(t, quality, 100)
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 400, 400), img.Bounds())
+}
+
+func BenchmarkWordcloud_DrawPNG(b *testing.B) {
+	words := map[string]int{"poster": 10, "size": 8, "cloud": 5, "memory": 3}
+	w := newTestCloud(b, words,
+		FontMaxSize(200),
+		Width(2048),
+		Height(2048),
+	)
+	b.Run("encode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.Reset(words)
+			assert.NoError(b, png.Encode(io.Discard, w.Draw()))
+		}
+	})
+	b.Run("drawpng", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			w.Reset(words)
+			assert.NoError(b, w.DrawPNG(io.Discard))
+		}
+	})
+}
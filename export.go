@@ -0,0 +1,160 @@
+package wordclouds
+
+import (
+	"encoding/csv"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+)
+
+// PlacedWord describes a word drawn on the canvas
+type PlacedWord struct {
+	Word  string
+	Count int
+	// Center of the word on the canvas
+	X float64
+	Y float64
+	// Font size
+	Size float64
+	// Rotation in degrees, counterclockwise
+	Angle float64
+	Color color.Color
+	// Box the word was placed in
+	Box Box
+	// Box reacting to clicks and taps, the placement box grown by HitBoxPadding within the canvas
+	HitBox Box
+}
+
+// PlacedWords returns the words drawn by Draw, in placement order. Positions and sizes are rounded to ExportPrecision.
+func (w *Wordcloud) PlacedWords() []PlacedWord {
+	res := w.placedWords()
+	for i := range res {
+		w.roundPlacedWord(&res[i])
+	}
+	return res
+}
+
+// placedWords returns the words drawn by Draw, in placement order, without rounding
+func (w *Wordcloud) placedWords() []PlacedWord {
+	res := make([]PlacedWord, 0, len(w.placed))
+	for _, pw := range w.placed {
+		res = append(res, PlacedWord{
+			Word:   pw.word,
+			Count:  pw.count,
+			X:      pw.x,
+			Y:      pw.y,
+			Size:   pw.size,
+			Angle:  pw.angle,
+			Color:  pw.color,
+			Box:    *pw.box,
+			HitBox: *w.hitBox(pw.box),
+		})
+	}
+	return res
+}
+
+// PlacedWordsNormalized returns the words drawn by Draw like PlacedWords, with positions and sizes as fractions of the
+// canvas: X, Y and the box sides are divided by the width or the height, and the font size in pixels by the height.
+func (w *Wordcloud) PlacedWordsNormalized() []PlacedWord {
+	res := w.placedWords()
+	for i := range res {
+		pw := &res[i]
+		pw.X /= w.width
+		pw.Y /= w.height
+		pw.Size = w.pixelSize(pw.Size) / w.height
+		pw.Box = w.normalizeBox(pw.Box)
+		pw.HitBox = w.normalizeBox(pw.HitBox)
+		w.roundPlacedWord(pw)
+	}
+	return res
+}
+
+// round rounds the value to ExportPrecision decimals. A negative precision leaves it as is.
+func (w *Wordcloud) round(f float64) float64 {
+	if w.opts.ExportPrecision < 0 {
+		return f
+	}
+	scale := math.Pow(10, float64(w.opts.ExportPrecision))
+	return math.Round(f*scale) / scale
+}
+
+// roundBox rounds the sides of the box to ExportPrecision decimals
+func (w *Wordcloud) roundBox(b Box) Box {
+	return Box{w.round(b.Top), w.round(b.Left), w.round(b.Right), w.round(b.Bottom)}
+}
+
+// roundPlacedWord rounds the positions and sizes of the word to ExportPrecision decimals
+func (w *Wordcloud) roundPlacedWord(pw *PlacedWord) {
+	pw.X = w.round(pw.X)
+	pw.Y = w.round(pw.Y)
+	pw.Size = w.round(pw.Size)
+	pw.Angle = w.round(pw.Angle)
+	pw.Box = w.roundBox(pw.Box)
+	pw.HitBox = w.roundBox(pw.HitBox)
+}
+
+// normalizeBox returns the box with its sides as fractions of the canvas
+func (w *Wordcloud) normalizeBox(b Box) Box {
+	return Box{b.Top / w.height, b.Left / w.width, b.Right / w.width, b.Bottom / w.height}
+}
+
+// hitBox returns the box grown by HitBoxPadding, within the canvas
+func (w *Wordcloud) hitBox(b *Box) *Box {
+	p := w.opts.HitBoxPadding
+	return &Box{
+		math.Min(b.Top+p, w.height),
+		math.Max(b.Left-p, 0),
+		math.Min(b.Right+p, w.width),
+		math.Max(b.Bottom-p, 0),
+	}
+}
+
+// ExportCSV writes one row per placed word, after a header row: word, count, x, y, size, angle, color.
+// Colors are written in hex notation. Call it after Draw.
+func (w *Wordcloud) ExportCSV(out io.Writer) error {
+	return exportCSV(out, w.PlacedWords())
+}
+
+// ExportCSVNormalized writes the same rows as ExportCSV, with the positions and sizes of PlacedWordsNormalized
+func (w *Wordcloud) ExportCSVNormalized(out io.Writer) error {
+	return exportCSV(out, w.PlacedWordsNormalized())
+}
+
+func exportCSV(out io.Writer, words []PlacedWord) error {
+	cw := csv.NewWriter(out)
+	err := cw.Write([]string{"word", "count", "x", "y", "size", "angle", "color"})
+	if err != nil {
+		return err
+	}
+	for _, pw := range words {
+		err = cw.Write([]string{
+			pw.Word,
+			strconv.Itoa(pw.Count),
+			formatFloat(pw.X),
+			formatFloat(pw.Y),
+			formatFloat(pw.Size),
+			formatFloat(pw.Angle),
+			colorHex(pw.Color),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// colorHex formats a color as #rrggbb, or #rrggbbaa if it is not opaque
+func colorHex(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
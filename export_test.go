@@ -0,0 +1,84 @@
+package wordclouds
+
+import (
+	"bytes"
+	"encoding/csv"
+	"image/color"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_ExportCSV(t *testing.T) {
+	words := map[string]int{"salt, pepper": 10, `say "cheese"`: 7, "two\nlines": 5, "plain": 3}
+	w := NewWordcloud(words,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, len(words))
+
+	var out bytes.Buffer
+	assert.NoError(t, w.ExportCSV(&out))
+	assert.Contains(t, out.String(), `"salt, pepper",10,`)
+	assert.Contains(t, out.String(), `"say ""cheese""",7,`)
+	assert.Contains(t, out.String(), "\"two\nlines\",5,")
+	assert.Contains(t, out.String(), "\nplain,3,")
+
+	rows, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"word", "count", "x", "y", "size", "angle", "color"}, rows[0])
+	exported := make(map[string]string)
+	for _, row := range rows[1:] {
+		assert.Len(t, row, 7)
+		exported[row[0]] = row[1]
+	}
+	assert.Equal(t, map[string]string{"salt, pepper": "10", `say "cheese"`: "7", "two\nlines": "5", "plain": "3"},
+		exported)
+}
+
+func TestWordcloud_ExportPrecision(t *testing.T) {
+	// Decimal places of a formatted number
+	decimals := func(s string) int {
+		if i := strings.Index(s, "."); i >= 0 {
+			return len(s) - i - 1
+		}
+		return 0
+	}
+	w := newTestCloud(t, map[string]int{"third": 10, "quarter": 7, "fifth": 3},
+		FontMaxSize(37),
+		Width(301),
+		Height(299),
+		RandomPlacement(true),
+		ExportPrecision(2),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, 3)
+
+	var out bytes.Buffer
+	assert.NoError(t, w.ExportCSVNormalized(&out))
+	rows, err := csv.NewReader(&out).ReadAll()
+	assert.NoError(t, err)
+	for _, row := range rows[1:] {
+		for _, value := range row[2:6] {
+			assert.True(t, decimals(value) <= 2, value)
+		}
+	}
+	for i, pw := range w.PlacedWords() {
+		assert.InDelta(t, w.placed[i].x, pw.X, 0.005)
+		for _, v := range []float64{pw.X, pw.Y, pw.Size, pw.Box.Top, pw.Box.Left, pw.HitBox.Right} {
+			assert.True(t, decimals(formatFloat(v)) <= 2, v)
+		}
+	}
+
+	var html strings.Builder
+	assert.NoError(t, w.RenderHTML(&html))
+	for _, m := range regexp.MustCompile(`(left|top): ([0-9.]+)px`).FindAllStringSubmatch(html.String(), -1) {
+		assert.True(t, decimals(m[2]) <= 2, m[2])
+	}
+}
@@ -0,0 +1,234 @@
+package wordclouds
+
+import (
+	"image"
+	"os"
+	"sync"
+	"unicode"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+// A font file parsed at most once
+type ttf struct {
+	once sync.Once
+	font *truetype.Font
+	err  error
+}
+
+type fontKey struct {
+	path string
+	size float64
+}
+
+// A font face loaded at most once, possibly ahead of time by another goroutine
+type fontFace struct {
+	once sync.Once
+	face font.Face
+	err  error
+}
+
+// parseFont returns the parsed font file, reading it if needed
+func (w *Wordcloud) parseFont(path string) (*truetype.Font, error) {
+	w.fontsMu.Lock()
+	t, ok := w.ttfs[path]
+	if !ok {
+		t = &ttf{}
+		w.ttfs[path] = t
+	}
+	w.fontsMu.Unlock()
+
+	t.once.Do(func() {
+		// The Go sans-serif font is bundled for clouds without a font file
+		b := goregular.TTF
+		if path != "" {
+			b, t.err = os.ReadFile(path)
+			if t.err != nil {
+				return
+			}
+		}
+		t.font, t.err = truetype.Parse(b)
+	})
+	return t.font, t.err
+}
+
+// loadFont returns the font face for the given font file and size, loading it if needed
+func (w *Wordcloud) loadFont(path string, size float64) (font.Face, error) {
+	key := fontKey{path, size}
+	w.fontsMu.Lock()
+	f, ok := w.fonts[key]
+	if !ok {
+		f = &fontFace{}
+		w.fonts[key] = f
+	}
+	w.fontsMu.Unlock()
+
+	f.once.Do(func() {
+		var ft *truetype.Font
+		ft, f.err = w.parseFont(path)
+		if f.err != nil {
+			return
+		}
+		f.face = truetype.NewFace(ft, &truetype.Options{Size: size, DPI: w.opts.DPI, Hinting: w.opts.Hinting})
+	})
+	return f.face, f.err
+}
+
+// covers tells whether the font has a glyph for every character of the text but spaces
+func covers(ft *truetype.Font, text string) bool {
+	for _, r := range text {
+		if !unicode.IsSpace(r) && ft.Index(r) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// loadFace returns the font face to draw the text with at the given size: the one of FontFile, or if FontFile misses
+// glyphs of the text, a face drawing each run of characters with the first of FontFile and FallbackFonts having them
+func (w *Wordcloud) loadFace(text string, size float64) (font.Face, error) {
+	face, err := w.loadFont(w.opts.FontFile, size)
+	if err != nil || len(w.opts.FallbackFonts) == 0 {
+		return face, err
+	}
+	ft, err := w.parseFont(w.opts.FontFile)
+	if err != nil {
+		return nil, err
+	}
+	if covers(ft, text) {
+		return face, nil
+	}
+
+	fallback := &fallbackFace{fonts: []*truetype.Font{ft}, faces: []font.Face{face}}
+	for _, path := range w.opts.FallbackFonts {
+		ft, err := w.parseFont(path)
+		if err != nil {
+			return nil, err
+		}
+		face, err := w.loadFont(path, size)
+		if err != nil {
+			return nil, err
+		}
+		fallback.fonts = append(fallback.fonts, ft)
+		fallback.faces = append(fallback.faces, face)
+	}
+	return fallback, nil
+}
+
+// fontFor returns the font drawing the character: the first of FontFile and FallbackFonts having a glyph for it, or
+// FontFile if none does, like a fallbackFace
+func (w *Wordcloud) fontFor(r rune) (*truetype.Font, error) {
+	primary, err := w.parseFont(w.opts.FontFile)
+	if err != nil || primary.Index(r) != 0 {
+		return primary, err
+	}
+	for _, path := range w.opts.FallbackFonts {
+		ft, err := w.parseFont(path)
+		if err != nil || ft.Index(r) != 0 {
+			return ft, err
+		}
+	}
+	return primary, nil
+}
+
+// face returns the font face to draw the text with at the given size
+func (w *Wordcloud) face(text string, size float64) font.Face {
+	if w.opts.FontProvider != nil {
+		f, err := w.providedFace(size)
+		if err != nil {
+			panic(err)
+		}
+		return f
+	}
+	f, err := w.loadFace(text, size)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// setFont sets the font face used to draw the text at the given size
+func (w *Wordcloud) setFont(text string, size float64) {
+	w.dc.SetFontFace(w.face(text, size))
+}
+
+// A face drawing each character with the first of its faces whose font has a glyph for it. Characters none of the
+// fonts has are drawn with the first face.
+type fallbackFace struct {
+	fonts []*truetype.Font
+	faces []font.Face
+}
+
+// run returns the face drawing the character
+func (f *fallbackFace) run(r rune) font.Face {
+	for i, ft := range f.fonts {
+		if ft.Index(r) != 0 {
+			return f.faces[i]
+		}
+	}
+	return f.faces[0]
+}
+
+func (f *fallbackFace) Close() error {
+	return nil
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (
+	image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return f.run(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return f.run(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return f.run(r).GlyphAdvance(r)
+}
+
+// Kern only applies within a run, between two characters drawn with the same face
+func (f *fallbackFace) Kern(r0 rune, r1 rune) fixed.Int26_6 {
+	face := f.run(r0)
+	if face != f.run(r1) {
+		return 0
+	}
+	return face.Kern(r0, r1)
+}
+
+// Metrics are the largest ones of the faces, so that lines leave room for the runs of every font
+func (f *fallbackFace) Metrics() font.Metrics {
+	m := f.faces[0].Metrics()
+	for _, face := range f.faces[1:] {
+		fm := face.Metrics()
+		if fm.Height > m.Height {
+			m.Height = fm.Height
+		}
+		if fm.Ascent > m.Ascent {
+			m.Ascent = fm.Ascent
+		}
+		if fm.Descent > m.Descent {
+			m.Descent = fm.Descent
+		}
+	}
+	return m
+}
+
+// preloadFonts loads the font faces of the upcoming words while the previous ones are being placed, until done is
+// closed. Loading errors are left for setFont to report.
+func (w *Wordcloud) preloadFonts(words []wordCount, done chan struct{}) {
+	for _, wc := range words {
+		select {
+		case <-done:
+			return
+		default:
+			if w.opts.FontProvider != nil {
+				w.providedFace(wc.size)
+			} else {
+				w.loadFace(w.text(wc), wc.size)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestWordcloud_PreloadFonts(t *testing.T) {
+	words := loadTestWords(t)
+	draw := func(prefetch bool) image.Image {
+		w := newTestCloud(t, words,
+			FontMaxSize(150),
+			FontMinSize(15),
+			Colors([]color.Color{
+				color.RGBA{0x1b, 0x1b, 0x1b, 0xff},
+				color.RGBA{0x59, 0x3a, 0xee, 0xff},
+				color.RGBA{0x70, 0xD6, 0xBF, 0xff},
+			}),
+			Width(1024),
+			Height(1024),
+		)
+		w.prefetchFonts = prefetch
+		rand.Seed(42)
+		return w.Draw()
+	}
+
+	assert.Equal(t, draw(false), draw(true))
+}
+
+func TestWordcloud_FallbackFonts(t *testing.T) {
+	fallback := filepath.Join(t.TempDir(), "goregular.ttf")
+	assert.NoError(t, os.WriteFile(fallback, goregular.TTF, 0o644))
+	w := newTestCloud(t, map[string]int{"Ơ→": 10},
+		FallbackFonts([]string{fallback}),
+	)
+
+	// Neither font covers the word: Roboto has no arrows and Go has no horned O
+	roboto, err := w.parseFont("testdata/Roboto-Regular.ttf")
+	assert.NoError(t, err)
+	goFont, err := w.parseFont(fallback)
+	assert.NoError(t, err)
+	assert.NotZero(t, roboto.Index('Ơ'))
+	assert.Zero(t, roboto.Index('→'))
+	assert.Zero(t, goFont.Index('Ơ'))
+	assert.NotZero(t, goFont.Index('→'))
+
+	robotoFace, err := w.loadFont("testdata/Roboto-Regular.ttf", 40)
+	assert.NoError(t, err)
+	goFace, err := w.loadFont(fallback, 40)
+	assert.NoError(t, err)
+
+	// Each run is measured with its own face
+	w.setFont("Ơ→", 40)
+	width, _ := w.measureString(w.dc, "Ơ→")
+	expected := font.MeasureString(robotoFace, "Ơ") + font.MeasureString(goFace, "→")
+	assert.Equal(t, float64(expected>>6), width)
+
+	// and drawn with it
+	word := gg.NewContext(100, 100)
+	word.SetColor(color.Black)
+	word.SetFontFace(w.face("Ơ→", 40))
+	word.DrawString("Ơ→", 10, 60)
+	runs := gg.NewContext(100, 100)
+	runs.SetColor(color.Black)
+	runs.SetFontFace(robotoFace)
+	runs.DrawString("Ơ", 10, 60)
+	runs.SetFontFace(goFace)
+	runs.DrawString("→", 10+float64(font.MeasureString(robotoFace, "Ơ"))/64, 60)
+	assert.Equal(t, runs.Image(), word.Image())
+}
+
+func TestWordcloud_DefaultFont(t *testing.T) {
+	w := NewWordcloud(map[string]int{"hello": 10, "world": 5},
+		Colors([]color.Color{color.Black}),
+		FontMaxSize(40),
+		Width(400),
+		Height(400),
+	)
+	assert.NotPanics(t, func() {
+		w.Draw()
+	})
+	assert.ElementsMatch(t, []string{"hello", "world"}, w.Result().Placed)
+}
+
+func TestWordcloud_Hinting(t *testing.T) {
+	advance := func(options ...Option) fixed.Int26_6 {
+		w := NewWordcloud(map[string]int{"hinted": 1}, append(options, FontFile("testdata/Roboto-Regular.ttf"))...)
+		a, ok := w.face("hinted", 13).GlyphAdvance('e')
+		assert.True(t, ok)
+		return a
+	}
+
+	// Fully hinted glyphs have whole pixel advances
+	assert.NotEqual(t, fixed.Int26_6(0), advance()&63)
+	assert.Equal(t, fixed.Int26_6(0), advance(Hinting(font.HintingFull))&63)
+}
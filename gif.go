@@ -0,0 +1,45 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+// Largest palette of a gif image
+const gifColors = 256
+
+// gifPalette returns the palette of EncodeGIF: the background color and the colors of the placed words, in placement
+// order, plus transparency for a CircleCrop. The palette holds at most 256 colors.
+func (w *Wordcloud) gifPalette() color.Palette {
+	p := color.Palette{w.opts.BackgroundColor}
+	if w.opts.CircleCrop {
+		p = append(p, color.Transparent)
+	}
+	for _, pw := range w.placed {
+		if len(p) == gifColors {
+			break
+		}
+		if !paletteContains(p, pw.color) {
+			p = append(p, pw.color)
+		}
+	}
+	return p
+}
+
+// EncodeGIF draws the cloud if it has not been drawn yet and encodes it as a single frame gif, with a palette made of
+// the background color and the colors of the words. Antialiased edges are dithered with GIFDithering, or take the
+// closest color of the palette.
+func (w *Wordcloud) EncodeGIF(out io.Writer) error {
+	img := w.image()
+	b := img.Bounds()
+	dst := image.NewPaletted(b, w.gifPalette())
+	if w.opts.GIFDithering {
+		draw.FloydSteinberg.Draw(dst, b, img, b.Min)
+	} else {
+		draw.Draw(dst, b, img, b.Min, draw.Src)
+	}
+	return gif.Encode(out, dst, nil)
+}
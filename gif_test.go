@@ -0,0 +1,41 @@
+package wordclouds
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_EncodeGIF(t *testing.T) {
+	colors := []color.Color{color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}, color.RGBA{G: 0x80, A: 0xff}}
+	for _, dither := range []bool{false, true} {
+		w := newTestCloud(t, map[string]int{"red": 10, "blue": 8, "green": 6, "more": 4, "words": 2},
+			FontMaxSize(40),
+			Width(300),
+			Height(200),
+			Colors(colors),
+			ColorCycle(true),
+			GIFDithering(dither),
+		)
+		var buf bytes.Buffer
+		assert.NoError(t, w.EncodeGIF(&buf))
+
+		g, err := gif.DecodeAll(bytes.NewReader(buf.Bytes()))
+		assert.NoError(t, err)
+		assert.Len(t, g.Image, 1)
+		frame := g.Image[0]
+		assert.Equal(t, image.Rect(0, 0, 300, 200), frame.Bounds())
+		// The background and the three word colors
+		assert.Len(t, frame.Palette, 4)
+		for _, c := range append(colors, color.White) {
+			assert.True(t, paletteContains(frame.Palette, c))
+		}
+		pw := w.placed[0]
+		assert.Equal(t, color.RGBAModel.Convert(w.output().At(int(pw.x), int(pw.y))),
+			color.RGBAModel.Convert(frame.At(int(pw.x), int(pw.y))))
+	}
+}
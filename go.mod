@@ -4,6 +4,7 @@ go 1.18
 
 require (
 	github.com/fogleman/gg v1.3.0
+	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0
 	github.com/google/uuid v1.3.1
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/image v0.0.0-20191009234506-e7c1f5e7dbb8
@@ -12,6 +13,5 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
-	github.com/golang/freetype v0.0.0-20170609003504-e2365dfdc4a0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 )
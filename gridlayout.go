@@ -0,0 +1,87 @@
+package wordclouds
+
+import (
+	"math"
+	"sort"
+)
+
+// cellBlock is a rectangle of cells of the grid layout
+type cellBlock struct {
+	col, row   int
+	cols, rows int
+}
+
+// nextCell returns the position of a word in the grid layout: the center of the free block of cells large enough for
+// the word that is closest to the center of the canvas. Blocks overlapping masks are skipped.
+func (w *Wordcloud) nextCell(width float64, height float64) (x float64, y float64, angle float64, space bool) {
+	cols, rows := w.opts.GridCols, w.opts.GridRows
+	cellWidth, cellHeight := w.width/float64(cols), w.height/float64(rows)
+	block := cellBlock{
+		cols: int(math.Ceil(width / cellWidth)),
+		rows: int(math.Ceil(height / cellHeight)),
+	}
+	if block.cols > cols || block.rows > rows {
+		return 0, 0, 0, false
+	}
+
+	center := func(b cellBlock) (float64, float64) {
+		return (float64(b.col) + float64(b.cols)/2) * cellWidth, (float64(b.row) + float64(b.rows)/2) * cellHeight
+	}
+	candidates := make([]cellBlock, 0, (cols-block.cols+1)*(rows-block.rows+1))
+	for row := 0; row+block.rows <= rows; row++ {
+		for col := 0; col+block.cols <= cols; col++ {
+			block.col, block.row = col, row
+			candidates = append(candidates, block)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		xi, yi := center(candidates[i])
+		xj, yj := center(candidates[j])
+		return math.Hypot(xi-w.width/2, yi-w.height/2) < math.Hypot(xj-w.width/2, yj-w.height/2)
+	})
+
+	for _, b := range candidates {
+		if !w.cellsFree(b) {
+			continue
+		}
+		x, y = center(b)
+		box := Box{y + height/2, x - width/2, x + width/2, y - height/2}
+		if !w.fits(w.withDescent(&box, 0)) {
+			continue
+		}
+		colliding := w.collides(&box)
+		if colliding {
+			continue
+		}
+		w.occupyCells(b)
+		return x, y, 0, true
+	}
+	return 0, 0, 0, false
+}
+
+func (w *Wordcloud) cellsFree(b cellBlock) bool {
+	for i := b.col; i < b.col+b.cols; i++ {
+		for j := b.row; j < b.row+b.rows; j++ {
+			if w.cells[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (w *Wordcloud) occupyCells(b cellBlock) {
+	for i := b.col; i < b.col+b.cols; i++ {
+		for j := b.row; j < b.row+b.rows; j++ {
+			w.cells[i][j] = true
+		}
+	}
+}
+
+// resetCells frees every cell of the grid layout
+func (w *Wordcloud) resetCells() {
+	w.cells = make([][]bool, w.opts.GridCols)
+	for i := range w.cells {
+		w.cells[i] = make([]bool, w.opts.GridRows)
+	}
+}
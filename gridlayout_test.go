@@ -0,0 +1,27 @@
+package wordclouds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_GridLayout(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		GridLayout(8, 20),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	for i, pw := range w.placed {
+		// Centers of blocks of cells are on cell edges or middles
+		assert.InDelta(t, 0, math.Mod(pw.x, 25), 1e-9, pw.word)
+		assert.InDelta(t, 0, math.Mod(pw.y, 10), 1e-9, pw.word)
+		for _, other := range w.placed[:i] {
+			assert.False(t, pw.box.overlaps(other.box), "%s overlaps %s", pw.word, other.word)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package wordclouds
+
+import (
+	"math"
+	"sort"
+)
+
+// groupOffsets returns, for each group of Groups, the shift of the spiral the words of the group are placed on. The
+// centers of the groups are spread evenly on a circle around the center of the spiral.
+func groupOffsets(opts *Options) map[string]point {
+	ids := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, id := range opts.Groups {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		return nil
+	}
+	sort.Strings(ids)
+
+	radius := math.Min(float64(opts.Width), float64(opts.Height)) / 4
+	offsets := make(map[string]point, len(ids))
+	for i, id := range ids {
+		angle := 2 * math.Pi * float64(i) / float64(len(ids))
+		offsets[id] = point{radius * math.Cos(angle), radius * math.Sin(angle)}
+	}
+	return offsets
+}
+
+// groupOffset returns the shift of the spiral for the word, none if it is in no group
+func (w *Wordcloud) groupOffset(word string) point {
+	id, ok := w.opts.Groups[word]
+	if !ok {
+		return point{}
+	}
+	return w.groupOffsets[id]
+}
@@ -0,0 +1,44 @@
+package wordclouds
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Groups(t *testing.T) {
+	words := loadTestWords(t)
+	names := make([]string, 0, len(words))
+	for word := range words {
+		names = append(names, word)
+	}
+	sort.Strings(names)
+	groups := make(map[string]string)
+	for i, word := range names {
+		groups[word] = []string{"a", "b"}[i%2]
+	}
+
+	w := newTestCloud(t, words,
+		FontMaxSize(40),
+		FontMinSize(10),
+		Groups(groups),
+	)
+	w.Draw()
+
+	centroids := make(map[string]point)
+	counts := make(map[string]float64)
+	for _, pw := range w.placed {
+		g := groups[pw.word]
+		centroids[g] = point{centroids[g].x + pw.x, centroids[g].y + pw.y}
+		counts[g]++
+	}
+	for g, c := range centroids {
+		centroids[g] = point{c.x / counts[g], c.y / counts[g]}
+	}
+	// The groups are centered 100px to the right and to the left of the center
+	assert.Greater(t, centroids["a"].x, 240.0)
+	assert.Less(t, centroids["b"].x, 160.0)
+	assert.InDelta(t, 200, centroids["a"].y, 40)
+	assert.InDelta(t, 200, centroids["b"].y, 40)
+}
@@ -0,0 +1,32 @@
+package wordclouds
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// RenderHTML writes the placed words as an HTML fragment: a div the size of the canvas holding one absolutely
+// positioned span per word, so that the words can be selected and read by screen readers. The font is left to the
+// page styles. Call it after Draw.
+func (w *Wordcloud) RenderHTML(out io.Writer) error {
+	_, err := fmt.Fprintf(out,
+		"<div class=\"wordcloud\" style=\"position: relative; width: %spx; height: %spx; background: %s;\">\n",
+		formatFloat(w.round(w.width)), formatFloat(w.round(w.height)), colorHex(w.opts.BackgroundColor))
+	if err != nil {
+		return err
+	}
+	for _, pw := range w.placed {
+		_, err = fmt.Fprintf(out,
+			"  <span style=\"position: absolute; left: %spx; top: %spx; font-size: %spx; color: %s; "+
+				"white-space: pre; line-height: %s; transform: translate(-50%%, -50%%) rotate(%sdeg);\">%s</span>\n",
+			formatFloat(w.round(pw.x)), formatFloat(w.round(pw.y)), formatFloat(w.round(w.pixelSize(pw.size))),
+			colorHex(pw.color), formatFloat(w.opts.LineSpacing), formatFloat(w.round(-pw.angle)),
+			html.EscapeString(pw.text))
+		if err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(out, "</div>")
+	return err
+}
@@ -0,0 +1,24 @@
+package wordclouds
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_RenderHTML(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"<b>&co": 10},
+		FontMaxSize(40),
+		Colors([]color.Color{color.RGBA{R: 0xff, A: 0xff}}),
+	)
+	w.Draw()
+
+	var out strings.Builder
+	assert.NoError(t, w.RenderHTML(&out))
+	assert.Contains(t, out.String(), ">&lt;b&gt;&amp;co</span>")
+	assert.Contains(t, out.String(), "color: #ff0000;")
+	assert.Contains(t, out.String(), "font-size: 40px;")
+	assert.NotContains(t, out.String(), "<b>")
+}
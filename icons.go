@@ -0,0 +1,31 @@
+package wordclouds
+
+import (
+	"image"
+
+	"github.com/fogleman/gg"
+)
+
+// icon returns the image drawn instead of the word, or nil if the word is drawn as text
+func (w *Wordcloud) icon(word string) image.Image {
+	return w.opts.Icons[word]
+}
+
+// iconSize returns the size of an icon scaled to the height of a font size, keeping its aspect ratio
+func iconSize(icon image.Image, size float64) (width float64, height float64) {
+	b := icon.Bounds()
+	if b.Dy() == 0 {
+		return 0, 0
+	}
+	return size * float64(b.Dx()) / float64(b.Dy()), size
+}
+
+// drawIcon draws the icon scaled to the height of a font size and centered on x, y
+func drawIcon(dc *gg.Context, icon image.Image, size float64, x float64, y float64) {
+	scale := size / float64(icon.Bounds().Dy())
+	dc.Push()
+	defer dc.Pop()
+	dc.Translate(x, y)
+	dc.Scale(scale, scale)
+	dc.DrawImageAnchored(icon, 0, 0, 0.5, 0.5)
+}
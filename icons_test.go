@@ -0,0 +1,42 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Icons(t *testing.T) {
+	green := color.RGBA{G: 0xff, A: 0xff}
+	icon := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	draw.Draw(icon, icon.Bounds(), image.NewUniform(green), image.Point{}, draw.Src)
+
+	w := newTestCloud(t, map[string]int{"logo": 10, "text": 5},
+		FontMaxSize(60),
+		BackgroundColor(color.White),
+		Icons(map[string]image.Image{"logo": icon}),
+	)
+	img := w.Draw()
+
+	assert.Len(t, w.placed, 2)
+	logo := w.placed[0]
+	assert.Equal(t, "logo", logo.word)
+	// Scaled to the font size, keeping the aspect ratio, plus the padding of word boxes
+	assert.InDelta(t, 125, logo.box.Right-logo.box.Left, 1e-9)
+	assert.InDelta(t, 65, logo.box.Top-logo.box.Bottom, 1e-9)
+	assert.Equal(t, color.RGBAModel.Convert(green), color.RGBAModel.Convert(img.At(int(logo.x), int(logo.y))))
+	assert.False(t, w.placed[1].box.overlaps(logo.box))
+}
+
+// discIcon returns a green disc on a transparent background
+func discIcon(size int) image.Image {
+	dc := gg.NewContext(size, size)
+	dc.SetRGB(0, 1, 0)
+	dc.DrawCircle(float64(size)/2, float64(size)/2, float64(size)/2)
+	dc.Fill()
+	return dc.Image()
+}
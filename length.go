@@ -0,0 +1,19 @@
+package wordclouds
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Length in runes up to which words are not shrunk by LengthPenalty
+const lengthPenaltyFree = 4
+
+// lengthFactor returns the factor applied to the font size of the word by LengthPenalty: (4/length)^penalty for the
+// words longer than 4 runes, 1 for the others
+func (w *Wordcloud) lengthFactor(word string) float64 {
+	n := utf8.RuneCountInString(word)
+	if w.opts.LengthPenalty <= 0 || n <= lengthPenaltyFree {
+		return 1
+	}
+	return math.Pow(float64(lengthPenaltyFree)/float64(n), w.opts.LengthPenalty)
+}
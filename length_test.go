@@ -0,0 +1,31 @@
+package wordclouds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_LengthPenalty(t *testing.T) {
+	words := map[string]int{"tiny": 10, "considerably": 10, "medium": 5}
+	sizes := func(options ...Option) map[string]float64 {
+		w := NewWordcloud(words, append(options, FontFile("testdata/Roboto-Regular.ttf"), FontMaxSize(60))...)
+		res := map[string]float64{}
+		for _, wc := range w.sortedWordList {
+			res[wc.word] = wc.size
+			mw, _ := w.MeasureWord(wc.word, wc.count)
+			plainWidth, _ := w.measureWord(gg.NewContext(1, 1), wc, wc.word)
+			assert.InDelta(t, plainWidth+5, mw, 1e-9)
+		}
+		return res
+	}
+
+	plain := sizes()
+	assert.Equal(t, plain["tiny"], plain["considerably"])
+	penalized := sizes(LengthPenalty(0.3))
+	assert.Equal(t, 60.0, penalized["tiny"])
+	assert.InDelta(t, 60*math.Pow(4.0/12, 0.3), penalized["considerably"], 1e-9)
+	assert.InDelta(t, 30*math.Pow(4.0/6, 0.3), penalized["medium"], 1e-9)
+}
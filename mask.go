@@ -1,6 +1,7 @@
 package wordclouds
 
 import (
+	"image"
 	"image/color"
 	"math"
 
@@ -9,12 +10,17 @@ import (
 
 // Mask creates a slice of box structs from a given mask image to be passed to wordclouds.MaskBoxes.
 func Mask(path string, width int, height int, exclude color.RGBA) []*Box {
-	res := make([]*Box, 0)
-
 	img, err := gg.LoadPNG(path)
 	if err != nil {
 		panic(err)
 	}
+	return maskBoxes(img, width, height, exclude)
+}
+
+// maskBoxes scales the mask image to the canvas and returns the boxes covering its pixels of the exclude color, and
+// the canvas areas the scaled image does not cover.
+func maskBoxes(img image.Image, width int, height int, exclude color.RGBA) []*Box {
+	res := make([]*Box, 0)
 
 	// scale
 	imgw := img.Bounds().Dx()
@@ -0,0 +1,57 @@
+package wordclouds
+
+// mirrorBox returns the reflection of the box across the vertical axis of the canvas
+func (w *Wordcloud) mirrorBox(b *Box) *Box {
+	return &Box{b.Top, w.width - b.Right, w.width - b.Left, b.Bottom}
+}
+
+// reflect draws the reflection of the word just placed across the vertical axis of the canvas, adds its boxes to the
+// grid and returns it. The reflection is at the mirrored position with the opposite angle, so that the letters read
+// the same way. Its boxes are the mirrored boxes of the word, except the boxes following the glyphs, which are not
+// mirrored when drawn and are computed again.
+func (w *Wordcloud) reflect(pw placedWord) placedWord {
+	// Boxes may be shared by the fields of a word
+	mirrored := make(map[*Box]*Box)
+	mirror := func(b *Box) *Box {
+		if b == nil {
+			return nil
+		}
+		if m, ok := mirrored[b]; ok {
+			return m
+		}
+		m := w.mirrorBox(b)
+		mirrored[b] = m
+		return m
+	}
+	m := pw
+	m.x = w.width - pw.x
+	m.angle = -pw.angle
+	m.box = mirror(pw.box)
+	m.bounds = mirror(pw.bounds)
+	w.renderWord(w.dc, &m, 0, 0)
+	if !w.opts.NoCollision && w.dominantSpace(pw.wordCount) <= 0 && pw.height > 40 {
+		// Like place does for large words
+		m.boxes = w.preciseBoxes(&m, m.bounds)
+	} else {
+		m.boxes = make([]*Box, len(pw.boxes))
+		for i, b := range pw.boxes {
+			m.boxes[i] = mirror(b)
+		}
+	}
+	if !w.opts.NoCollision {
+		// The words placed next are tested against the reflection too
+		for _, b := range m.boxes {
+			w.grid.Add(b)
+		}
+	}
+	return m
+}
+
+// mirrorWords adds the reflections of the placed words after them, in the order the words were placed
+func (w *Wordcloud) mirrorWords() {
+	for _, m := range w.reflections {
+		w.placed = append(w.placed, m)
+		w.wordsArea += m.box.area()
+	}
+	w.reflections = nil
+}
@@ -0,0 +1,60 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_MirrorSymmetry(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(40),
+		FontMinSize(10),
+		AngleFromRadius(30),
+		MirrorSymmetry(true),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	assert.Equal(t, 0, len(w.placed)%2)
+	n := len(w.placed) / 2
+	for i, pw := range w.placed[:n] {
+		assert.Less(t, pw.box.Right, 200.0, pw.word)
+		m := w.placed[n+i]
+		assert.Equal(t, pw.word, m.word)
+		assert.InDelta(t, 400-pw.x, m.x, 1e-9, pw.word)
+		assert.InDelta(t, pw.y, m.y, 1e-9, pw.word)
+		assert.Equal(t, -pw.angle, m.angle, pw.word)
+		assert.Equal(t, Box{pw.box.Top, 400 - pw.box.Right, 400 - pw.box.Left, pw.box.Bottom}, *m.box, pw.word)
+		assert.Equal(t, Box{pw.bounds.Top, 400 - pw.bounds.Right, 400 - pw.bounds.Left, pw.bounds.Bottom}, *m.bounds,
+			pw.word)
+		// The boxes follow the drawn reflection, within its bounds and margin
+		assert.Len(t, m.boxes, len(pw.boxes), pw.word)
+		for _, b := range m.boxes {
+			assert.GreaterOrEqual(t, b.Left, m.bounds.Left-outlineMargin-1, pw.word)
+			assert.LessOrEqual(t, b.Right, m.bounds.Right+outlineMargin+1, pw.word)
+			assert.GreaterOrEqual(t, b.Bottom, m.bounds.Bottom-outlineMargin-1, pw.word)
+			assert.LessOrEqual(t, b.Top, m.bounds.Top+outlineMargin+1, pw.word)
+		}
+	}
+	assert.Empty(t, w.VerifyNoOverlap())
+}
+
+func TestWordcloud_MirrorSymmetryMask(t *testing.T) {
+	// The mask only covers the right half
+	mask := &Box{300, 220, 400, 0}
+	w := newTestCloud(t, nil,
+		FontMaxSize(40),
+		FontMinSize(10),
+		MaskBoxes([]*Box{mask}),
+		MirrorSymmetry(true),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		for _, b := range pw.boxes {
+			assert.False(t, b.overlaps(mask), pw.word)
+		}
+	}
+}
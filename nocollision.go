@@ -0,0 +1,36 @@
+package wordclouds
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Angle between two consecutive points of the spiral followed by words placed without collision tests
+var goldenAngle = math.Pi * (3 - math.Sqrt(5))
+
+// nextUnchecked returns a position for a word placed without collision tests: the next point of a spiral spreading
+// the words over the canvas, or a random one. The box of the word and its descenders are kept on the canvas.
+func (w *Wordcloud) nextUnchecked(width float64, height float64) (x float64, y float64, angle float64, space bool) {
+	minX, maxX := width/2+w.opts.EdgeMargin, w.width-width/2-w.opts.EdgeMargin
+	minY, maxY := height/2+w.opts.EdgeMargin, w.height-height/2-w.placingDescent-w.opts.EdgeMargin
+
+	defer func() {
+		w.attempt.tries++
+		w.attempt.radius = math.Hypot(x-w.width/2, y-w.height/2)
+	}()
+
+	if w.randomPlacement {
+		x = minX + rand.Float64()*(maxX-minX)
+		y = minY + rand.Float64()*(maxY-minY)
+		return x, y, 0, true
+	}
+
+	// Points of the spiral are evenly spread over the disc inscribed in the canvas
+	n := float64(w.unchecked)
+	w.unchecked++
+	r := math.Sqrt((n+0.5)/float64(len(w.sortedWordList))) * math.Min(w.width, w.height) / 2
+	x = w.width/2 + r*math.Cos(n*goldenAngle)
+	y = w.height/2 + r*math.Sin(n*goldenAngle)
+	x, y = math.Min(math.Max(x, minX), maxX), math.Min(math.Max(y, minY), maxY)
+	return x, y, 0, true
+}
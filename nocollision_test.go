@@ -0,0 +1,28 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_NoCollision(t *testing.T) {
+	words := loadTestWords(t)
+	for _, random := range []bool{false, true} {
+		w := newTestCloud(t, words,
+			FontMaxSize(60),
+			FontMinSize(10),
+			RandomPlacement(random),
+			NoCollision(true),
+		)
+		w.Draw()
+
+		assert.Len(t, w.placed, len(words))
+		for _, pw := range w.placed {
+			assert.GreaterOrEqual(t, pw.box.Left, 0.0, pw.word)
+			assert.GreaterOrEqual(t, pw.box.Bottom, 0.0, pw.word)
+			assert.LessOrEqual(t, pw.box.Right, 400.0, pw.word)
+			assert.LessOrEqual(t, pw.box.Top, 400.0, pw.word)
+		}
+	}
+}
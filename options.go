@@ -1,7 +1,12 @@
 package wordclouds
 
 import (
+	"image"
 	"image/color"
+	"time"
+
+	"github.com/fogleman/gg"
+	"golang.org/x/image/font"
 )
 
 type Options struct {
@@ -16,25 +21,127 @@ type Options struct {
 	Mask            []*Box
 	SizeFunction    sizeFunction
 	Debug           bool
+	RetryPasses     int
+	QuantizePalette bool
+	LineSpacing     float64
+	EdgeMargin      float64
+	ShowCounts      bool
+	// Space reserved around dominant words, relative to their font size
+	DominantWordSpace float64
+	// Font size from which a word is dominant
+	DominantWordMinSize float64
+	PreciseScanStep     int
+	Watermark           WatermarkOptions
+	FallbackFonts       []string
+	BalanceFill         bool
+	TierStyles          []TierStyle
+	MaskSVGPath         string
+	MaskSVGWidth        int
+	MaskSVGHeight       int
+	RotateToFit         bool
+	Relations           []Relation
+	RelationStyle       RelationStyle
+	RandomMaxTries      int
+	OpacityFunc         func(count int, maxCount int) float64
+	CenterWord          string
+	DisplayTransform    func(word string) string
+	GridCellSize        int
+	AutoGridCellSize    bool
+	RadialLayout        bool
+	Categories          map[string]string
+	CategoryColors      map[string]color.Color
+	NonPositiveCounts   NonPositiveMode
+	Context             *gg.Context
+	NoCollision         bool
+	AngleFromRadius     float64
+	WordValues          map[string]float64
+	ValueMin            float64
+	ValueMax            float64
+	ValueColormap       Colormap
+	DPI                 float64
+	BoxesOnly           bool
+	MinRadius           float64
+	MaxRadius           float64
+	Region              []Point
+	Telemetry           func(word string, tries int, radius float64, dur time.Duration)
+	GridCols            int
+	GridRows            int
+	ColorModel          color.Model
+	EdgeFade            float64
+	Title               string
+	TitleOptions        TitleOptions
+	PlacementBias       float64
+	Icons               map[string]image.Image
+	EmbedWordList       bool
+	ColorByRadius       func(radius float64, maxRadius float64) color.Color
+	CollisionFunc       func(a *Box, b *Box) bool
+	CollisionReach      float64
+	BackgroundGradient  []GradientStop
+	GradientKind        GradientKind
+	AutoContrast        bool
+	BaselineBands       int
+	OnWordDropped       func(word string, count int, attemptedSize float64)
+	Oversize            OversizeMode
+	LineHeightFactor    float64
+	Groups              map[string]string
+	HitBoxPadding       float64
+	RiverAmplitude      float64
+	RiverWavelength     float64
+	ExcludeWords        []string
+	ColorCycle          bool
+	Anchor              Anchor
+	PremultipliedAlpha  bool
+	AutoPalette         int
+	Bar                 *FrequencyBar
+	PreciseScanChunk    int
+	CircleCrop          bool
+	RepeatMode          int
+	Hinting             font.Hinting
+	TokenOptions        []CountOption
+	ExportPrecision     int
+	StrokeWidth         func(size float64) float64
+	StrokeColor         color.Color
+	WordPadding         map[string]float64
+	SphereStrength      float64
+	MergeCaseVariants   bool
+	RecordAttempts      bool
+	FontSizeSteps       []float64
+	GIFDithering        bool
+	LengthPenalty       float64
+	FontProvider        FontProvider
+	OutlineBand         float64
+	Treemap             bool
+	WordTimeout         time.Duration
+	Mirror              bool
 }
 
 var defaultOptions = Options{
-	FontMaxSize:     500,
-	FontMinSize:     10,
-	RandomPlacement: false,
-	FontFile:        "",
-	Colors:          []color.Color{color.RGBA{}},
-	BackgroundColor: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
-	Width:           2048,
-	Height:          2048,
-	Mask:            make([]*Box, 0),
-	SizeFunction:    sizeLinear,
-	Debug:           false,
+	FontMaxSize:         500,
+	FontMinSize:         10,
+	RandomPlacement:     false,
+	FontFile:            "",
+	Colors:              []color.Color{color.RGBA{}},
+	BackgroundColor:     color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+	Width:               2048,
+	Height:              2048,
+	Mask:                make([]*Box, 0),
+	SizeFunction:        sizeLinear,
+	Debug:               false,
+	RetryPasses:         0,
+	QuantizePalette:     false,
+	LineSpacing:         1.0,
+	EdgeMargin:          0,
+	ShowCounts:          false,
+	DominantWordSpace:   0,
+	DominantWordMinSize: 0,
+	PreciseScanStep:     5,
+	LineHeightFactor:    1.0,
+	ExportPrecision:     -1,
 }
 
 type Option func(*Options)
 
-// Path to font file
+// Path to font file. Defaults to a bundled sans-serif font.
 func FontFile(path string) Option {
 	return func(options *Options) {
 		options.FontFile = path
@@ -118,3 +225,664 @@ func Debug() Option {
 		options.Debug = true
 	}
 }
+
+// Number of extra passes retrying the words that did not fit, each time at a reduced size
+func RetryPasses(n int) Option {
+	return func(options *Options) {
+		options.RetryPasses = n
+	}
+}
+
+// Map the output to the background and word colors only. Draw then returns an *image.Paletted
+func QuantizePalette(do bool) Option {
+	return func(options *Options) {
+		options.QuantizePalette = do
+	}
+}
+
+// Spacing between the lines of words containing a newline, as a multiple of the font height
+func LineSpacing(spacing float64) Option {
+	return func(options *Options) {
+		options.LineSpacing = spacing
+	}
+}
+
+// Minimum distance in pixels between the words and the edges of the canvas
+func EdgeMargin(px float64) Option {
+	return func(options *Options) {
+		options.EdgeMargin = px
+	}
+}
+
+// Append the count to each word, e.g. "golang (42)"
+func ShowCounts(do bool) Option {
+	return func(options *Options) {
+		options.ShowCounts = do
+	}
+}
+
+// Reserve empty space around words with a font size of at least minSize, proportional to their size.
+// A ratio of 0.2 keeps other words at least 0.2*size pixels away from them.
+func DominantWordSpace(ratio float64, minSize float64) Option {
+	return func(options *Options) {
+		options.DominantWordSpace = ratio
+		options.DominantWordMinSize = minSize
+	}
+}
+
+// Step in pixels of the scan computing the bounding boxes of large icons and of large words drawn with a
+// FontProvider. A smaller step packs words tighter but is slower. The boxes of other large text words follow the
+// outlines of their glyphs.
+func PreciseScanStep(px int) Option {
+	return func(options *Options) {
+		options.PreciseScanStep = px
+	}
+}
+
+// Draw a small text in a corner of the canvas. Words are not placed over it.
+func Watermark(text string, position Corner, c color.Color, size float64) Option {
+	return func(options *Options) {
+		options.Watermark = WatermarkOptions{
+			Text:     text,
+			Position: position,
+			Color:    c,
+			Size:     size,
+		}
+	}
+}
+
+// Fonts used, in order, for the words with characters that FontFile has no glyph for
+func FallbackFonts(paths []string) Option {
+	return func(options *Options) {
+		options.FallbackFonts = paths
+	}
+}
+
+// Prefer the emptiest positions on each circle, to fill masked shapes more evenly.
+// Only applies to the circular placement and makes it slower.
+func BalanceFill(do bool) Option {
+	return func(options *Options) {
+		options.BalanceFill = do
+	}
+}
+
+// Split the words in tiers by decreasing count, each with its own colors and size scaling
+func TierStyles(tiers []TierStyle) Option {
+	return func(options *Options) {
+		options.TierStyles = tiers
+	}
+}
+
+// Place words only inside an SVG path. d is the path data, as in the d attribute of a <path> element, in a
+// width x height coordinate system that is scaled to the canvas like a mask image.
+func MaskSVGPath(d string, width int, height int) Option {
+	return func(options *Options) {
+		options.MaskSVGPath = d
+		options.MaskSVGWidth = width
+		options.MaskSVGHeight = height
+	}
+}
+
+// Rotate words by 90 degrees where they only fit vertically. Only applies to the circular placement.
+func RotateToFit(do bool) Option {
+	return func(options *Options) {
+		options.RotateToFit = do
+	}
+}
+
+// Draw lines between the related words once they are placed
+func Relations(relations []Relation, style RelationStyle) Option {
+	return func(options *Options) {
+		options.Relations = relations
+		options.RelationStyle = style
+	}
+}
+
+// Maximum number of positions tried by the random placement for each word. By default it decreases as the canvas
+// fills up, from 100000 to 1000.
+func RandomMaxTries(n int) Option {
+	return func(options *Options) {
+		options.RandomMaxTries = n
+	}
+}
+
+// Set the opacity of each word from its count and the highest count, from 0 (invisible) to 1 (opaque)
+func OpacityFunc(f func(count int, maxCount int) float64) Option {
+	return func(options *Options) {
+		options.OpacityFunc = f
+	}
+}
+
+// Draw this word first, at the center of the canvas or of the area left free by the mask, whatever its count
+func CenterWord(word string) Option {
+	return func(options *Options) {
+		options.CenterWord = word
+	}
+}
+
+// Transform the words before they are measured and drawn, e.g. strings.ToUpper. The words keep their original
+// spelling everywhere else, such as in Result or CenterWord.
+func DisplayTransform(transform func(word string) string) Option {
+	return func(options *Options) {
+		options.DisplayTransform = transform
+	}
+}
+
+// Size in pixels of the cells of the grid used to detect collisions. Smaller cells hold fewer boxes to test but a
+// word spans more of them. Defaults to about 10 pixels, takes precedence over AutoGridCellSize.
+func GridCellSize(size int) Option {
+	return func(options *Options) {
+		options.GridCellSize = size
+	}
+}
+
+// Pick the size of the collision grid cells from the median dimension of the measured words
+func AutoGridCellSize(auto bool) Option {
+	return func(options *Options) {
+		options.AutoGridCellSize = auto
+	}
+}
+
+// Orient the words along the radius of the spiral they are placed on, for a sunburst effect.
+// Has no effect with RandomPlacement.
+func RadialLayout(radial bool) Option {
+	return func(options *Options) {
+		options.RadialLayout = radial
+	}
+}
+
+// Draw a chip behind the words that have a category, colored after the category. categories maps words to their
+// category and colors maps categories to the color of their chips.
+func CategoryChips(categories map[string]string, colors map[string]color.Color) Option {
+	return func(options *Options) {
+		options.Categories = categories
+		options.CategoryColors = colors
+	}
+}
+
+// NonPositiveMode tells what to do with the words whose count is zero or negative
+type NonPositiveMode int
+
+const (
+	// SkipNonPositive leaves the words out of the cloud
+	SkipNonPositive NonPositiveMode = iota
+	// ClampNonPositive draws the words at the minimum font size
+	ClampNonPositive
+)
+
+// Choose what to do with the words whose count is zero or negative. They are skipped by default.
+func NonPositiveCounts(mode NonPositiveMode) Option {
+	return func(options *Options) {
+		options.NonPositiveCounts = mode
+	}
+}
+
+// Draw on this context instead of a new one, keeping its state such as line caps, dashes or transform. Its size must
+// be the one set by Width and Height, or NewWordcloud panics. It is cleared with the background color when the
+// wordcloud is created, and belongs to the wordcloud from then on: the image returned by Draw is the one of the
+// context, and the context must not be drawn on while Draw runs.
+func WithContext(dc *gg.Context) Option {
+	return func(options *Options) {
+		options.Context = dc
+	}
+}
+
+// Place the words without testing for collisions, so that they overlap by design. Words follow a spiral from the
+// center, or random positions with RandomPlacement, and masks are ignored. Much faster, and best combined with
+// OpacityFunc for a foggy look.
+func NoCollision(do bool) Option {
+	return func(options *Options) {
+		options.NoCollision = do
+	}
+}
+
+// Rotate the words by up to maxDeg degrees, counterclockwise, the farther from the center the more.
+// Has no effect with RandomPlacement.
+func AngleFromRadius(maxDeg float64) Option {
+	return func(options *Options) {
+		options.AngleFromRadius = maxDeg
+	}
+}
+
+// Color the words by their value instead of their count, e.g. a sentiment score. Values from min to max are mapped
+// through the colormap, such as Viridis or BlueRed. Words without a value keep the usual colors.
+func WordValues(values map[string]float64, min float64, max float64, colormap Colormap) Option {
+	return func(options *Options) {
+		options.WordValues = values
+		options.ValueMin = min
+		options.ValueMax = max
+		options.ValueColormap = colormap
+	}
+}
+
+// Set the canvas size in inches or millimeters for print. The pixel size is computed from the resolution in dots per
+// inch, and font sizes are taken in points at that resolution.
+func PhysicalSize(width float64, height float64, unit Unit, dpi float64) Option {
+	return func(options *Options) {
+		options.Width = ToPixels(width, unit, dpi)
+		options.Height = ToPixels(height, unit, dpi)
+		options.DPI = dpi
+	}
+}
+
+// Output the boxes used to detect collisions instead of the words, to see how space is allocated: the outline of
+// the word boxes, over the filled mask boxes. Placement is unchanged.
+func BoxesOnly(do bool) Option {
+	return func(options *Options) {
+		options.BoxesOnly = do
+	}
+}
+
+// Keep the center of the words at least this far from the center of the canvas, leaving it hollow.
+// Has no effect with RandomPlacement.
+func MinRadius(radius float64) Option {
+	return func(options *Options) {
+		options.MinRadius = radius
+	}
+}
+
+// Keep the center of the words at most this far from the center of the canvas. Words that do not fit within are
+// skipped. Has no effect with RandomPlacement.
+func MaxRadius(radius float64) Option {
+	return func(options *Options) {
+		options.MaxRadius = radius
+	}
+}
+
+// Keep the words inside a polygon, given by its vertices in canvas coordinates. It may be concave.
+func Region(polygon []Point) Option {
+	return func(options *Options) {
+		options.Region = polygon
+	}
+}
+
+// Call f after each attempt to place a word, with the number of positions tested, the distance of the word to the
+// center of the canvas and how long the attempt took. The radius is negative if the word was not placed.
+func Telemetry(f func(word string, tries int, radius float64, dur time.Duration)) Option {
+	return func(options *Options) {
+		options.Telemetry = f
+	}
+}
+
+// Lay the words out on a grid of cols x rows cells instead of a spiral, for a structured look. Each word takes as many
+// cells as its size needs, as close to the center as possible.
+func GridLayout(cols int, rows int) Option {
+	return func(options *Options) {
+		options.GridCols = cols
+		options.GridRows = rows
+	}
+}
+
+// Convert the image returned by Draw to a color model of the image/color package, e.g. color.GrayModel for an
+// *image.Gray. Applied after QuantizePalette.
+func ColorModel(model color.Model) Option {
+	return func(options *Options) {
+		options.ColorModel = model
+	}
+}
+
+// Tint the words toward the background color the farther they are from the center, for a vignette effect. strength
+// is the share of the background color in the words in the corners, from 0 to 1.
+func EdgeFade(strength float64) Option {
+	return func(options *Options) {
+		options.EdgeFade = strength
+	}
+}
+
+// Draw a title in a band at the top of the canvas. Words are placed below the band.
+func Title(text string, opts TitleOptions) Option {
+	return func(options *Options) {
+		options.Title = text
+		options.TitleOptions = opts
+	}
+}
+
+// Move the center of the placement spiral toward the upper left, where left-to-right readers start, so that the most
+// frequent words lean that way. 0 keeps the center of the canvas, 1 moves it to the center of the upper left quarter.
+// Has no effect with RandomPlacement.
+func PlacementBias(bias float64) Option {
+	return func(options *Options) {
+		options.PlacementBias = bias
+	}
+}
+
+// Draw the words having an icon as their icon instead of text, as high as their font size. Icons and text are laid
+// out together, so a cloud can mix both.
+func Icons(icons map[string]image.Image) Option {
+	return func(options *Options) {
+		options.Icons = icons
+	}
+}
+
+// Embed the word list as JSON in the png files written by Encode and DrawPNG, so that the data travels with the
+// image. Read it back with ReadPNGWordList.
+func EmbedWordList(do bool) Option {
+	return func(options *Options) {
+		options.EmbedWordList = do
+	}
+}
+
+// Color the words by where they land instead of by count: f gets the distance of a word to the center of the spiral,
+// which is the radius it was placed at, and the largest distance a word can be at. E.g. return a color per band of
+// radius for concentric rings. Overrides the other colorings, EdgeFade still applies.
+func ColorByRadius(f func(radius float64, maxRadius float64) color.Color) Option {
+	return func(options *Options) {
+		options.ColorByRadius = f
+	}
+}
+
+// Decide whether a word can be placed with a custom predicate instead of the boxes overlapping, e.g. to allow a small
+// overlap or to require a minimum gap. f is called with a box already on the canvas, a word or a mask, and the box
+// being tested, and returns true when they collide. Only the boxes within one cell of the collision grid around the
+// tested box are passed to it, see GridCellSize, so a minimum gap larger than a cell needs CollisionReach. It is called
+// in the hot path of the placement and must be cheap.
+func CollisionFunc(f func(a *Box, b *Box) bool) Option {
+	return func(options *Options) {
+		options.CollisionFunc = f
+	}
+}
+
+// Pass the boxes up to distance pixels away from the tested box to the CollisionFunc, instead of the ones within one
+// cell of the collision grid. The larger the distance, the more boxes are tested.
+func CollisionReach(distance float64) Option {
+	return func(options *Options) {
+		options.CollisionReach = distance
+	}
+}
+
+// Fill the canvas with a gradient instead of the flat background color, e.g. from a light color at offset 0 to a darker
+// one at offset 1. Transparent stops show the background color, which is still the one words fade toward.
+func BackgroundGradient(stops []GradientStop, kind GradientKind) Option {
+	return func(options *Options) {
+		options.BackgroundGradient = stops
+		options.GradientKind = kind
+	}
+}
+
+// Darken or lighten the words whose color is too close to the background under them, sampled from the canvas so that
+// gradients and images drawn on a context given to WithContext are taken into account. Applied after the other
+// colorings.
+func AutoContrast(do bool) Option {
+	return func(options *Options) {
+		options.AutoContrast = do
+	}
+}
+
+// Align the words in n horizontal bands of the canvas, like ruled lines: words are centered vertically on the band
+// they are placed in while their horizontal position stays free. Applies to the spiral and random placements.
+func BaselineBands(n int) Option {
+	return func(options *Options) {
+		options.BaselineBands = n
+	}
+}
+
+// Call f as soon as a word fails to be placed by Draw, with the font size it was tried at. With RetryPasses, a word
+// may be reported once per pass, then placed at a smaller size.
+func OnWordDropped(f func(word string, count int, attemptedSize float64)) Option {
+	return func(options *Options) {
+		options.OnWordDropped = f
+	}
+}
+
+// Choose what happens when the largest word does not fit the canvas at its size, e.g. a long word on a narrow canvas:
+// skip it, shrink it or scale all the words down with it. Defaults to SkipOversize.
+func Oversize(mode OversizeMode) Option {
+	return func(options *Options) {
+		options.Oversize = mode
+	}
+}
+
+// Multiply the measured height of the words by f for their boxes. The height of a text is the line height of the
+// font, a factor below 1 packs words tighter with fonts leaving large gaps above and below the letters. Defaults to 1.
+func LineHeightFactor(f float64) Option {
+	return func(options *Options) {
+		options.LineHeightFactor = f
+	}
+}
+
+// Place related words near each other: groups maps words to a group id, and the words of each group are placed around
+// their own center instead of the center of the canvas, forming clusters. Words without a group are placed around
+// the center. Applies to the spiral placement.
+func Groups(groups map[string]string) Option {
+	return func(options *Options) {
+		options.Groups = groups
+	}
+}
+
+// Grow the hit boxes reported by PlacedWords by px on each side, to make larger click and tap targets for image maps.
+// The layout is not changed, so hit boxes of neighboring words may overlap.
+func HitBoxPadding(px float64) Option {
+	return func(options *Options) {
+		options.HitBoxPadding = px
+	}
+}
+
+// Lay the words out along a sine wave across the canvas, from left to right, each word following the slope of the
+// wave. The wave has the given amplitude and wavelength in pixels. Once the wave is full, words are placed on lanes
+// above and below it.
+func WordRiver(amplitude float64, wavelength float64) Option {
+	return func(options *Options) {
+		options.RiverAmplitude = amplitude
+		options.RiverWavelength = wavelength
+	}
+}
+
+// Leave out these words of the word list, regardless of case, e.g. to hide words from a cached count for one render
+func ExcludeWords(words []string) Option {
+	return func(options *Options) {
+		options.ExcludeWords = words
+	}
+}
+
+// Give the words the colors of the palette in order instead of at random: the first placed word gets the first color,
+// the next one the second color and so on, starting over at the end of the palette
+func ColorCycle(do bool) Option {
+	return func(options *Options) {
+		options.ColorCycle = do
+	}
+}
+
+// Move the words to a corner or a side of the canvas once laid out, e.g. to leave room for other elements next to a
+// cloud that does not fill the canvas. The words keep their relative positions. Defaults to AnchorCenter.
+func AnchorCloud(anchor Anchor) Option {
+	return func(options *Options) {
+		options.Anchor = anchor
+	}
+}
+
+// Mix colors with premultiplied alpha, for clouds composited downstream over other images. With a transparent
+// background, words faded by EdgeFade then only get more transparent instead of also getting darker. The image returned
+// by Draw is always a premultiplied *image.RGBA, converted back after QuantizePalette and ColorModel if needed.
+func PremultipliedAlpha(do bool) Option {
+	return func(options *Options) {
+		options.PremultipliedAlpha = do
+	}
+}
+
+// Generate a palette of n colors when none is given with Colors: hues evenly spread around the complement of the
+// background hue, all legible on the background color or on the average of the BackgroundGradient
+func AutoPalette(n int) Option {
+	return func(options *Options) {
+		options.AutoPalette = n
+	}
+}
+
+// Draw a bar on the right of each word, its length showing the count of the word. The bars are part of the boxes of
+// the words.
+func BarStyle(bar FrequencyBar) Option {
+	return func(options *Options) {
+		options.Bar = &bar
+	}
+}
+
+// Scan the pixels of large icons and FontProvider words in chunks of rows lines of PreciseScanStep pixels, coalescing
+// the pixels of a line and the identical lines of a chunk into larger boxes. It adds far fewer boxes to the collision
+// grid.
+func PreciseScanChunk(rows int) Option {
+	return func(options *Options) {
+		options.PreciseScanChunk = rows
+	}
+}
+
+// Crop the drawn image to the largest circle centered on it, the pixels outside it being transparent. With a color
+// model without alpha they are of the background color instead.
+func CircleCrop(do bool) Option {
+	return func(options *Options) {
+		options.CircleCrop = do
+	}
+}
+
+// Draw each word as many times as its count, up to maxInstances times, at the minimum font size instead of sizing it
+// by its count. The density of a word shows its frequency.
+func RepeatMode(maxInstances int) Option {
+	return func(options *Options) {
+		options.RepeatMode = maxInstances
+	}
+}
+
+// Hinting of the glyphs. font.HintingFull snaps the glyphs to the pixel grid, for crisper small text, while the default
+// font.HintingNone keeps their shapes and advances as designed, for smooth scaling.
+func Hinting(hinting font.Hinting) Option {
+	return func(options *Options) {
+		options.Hinting = hinting
+	}
+}
+
+// Count the tokens of NewWordcloudFromTokens with these options, e.g. Normalizer(strings.ToLower) to count them
+// case-insensitively
+func TokenOptions(options ...CountOption) Option {
+	return func(opts *Options) {
+		opts.TokenOptions = options
+	}
+}
+
+// Round the positions and sizes of PlacedWords, PlacedWordsNormalized, ExportCSV and RenderHTML to this number of
+// decimals, so that exports of the same cloud do not change with float noise. Placement is unchanged. The default of
+// -1 keeps them as computed.
+func ExportPrecision(decimals int) Option {
+	return func(options *Options) {
+		options.ExportPrecision = decimals
+	}
+}
+
+// Stroke the outlines of the glyphs of the words, with a width in pixels given by width from the font size of the
+// word in pixels, e.g. func(size float64) float64 { return size / 20 } for bold outlines on large words. The strokes
+// have the color c, or the color of the word if c is nil, and are part of the boxes of the words. Words are not
+// stroked with WithFontProvider, which gives no glyph outlines.
+func WordStroke(width func(size float64) float64, c color.Color) Option {
+	return func(options *Options) {
+		options.StrokeWidth = width
+		options.StrokeColor = c
+	}
+}
+
+// Leave more room around some words, e.g. acronyms or numbers. padding maps words to the pixels added on each side of
+// their box, on top of the padding of every word. Words are matched regardless of case.
+func WordPadding(padding map[string]float64) Option {
+	return func(options *Options) {
+		options.WordPadding = padding
+	}
+}
+
+// Project the laid out words onto a sphere, for a globe of words: words are shrunk and drawn closer together the
+// farther they are from the center of the canvas. strength goes from 0, leaving the words as placed, to 1, where the
+// words in the corners of the canvas vanish on the rim of the sphere. Only the positions and sizes of the words
+// change, the letters are not warped, and the sizes are snapped down to FontSizeSteps. Words moved onto a mask, the
+// title or the watermark, or out of the region, are skipped.
+func SphereProjection(strength float64) Option {
+	return func(options *Options) {
+		options.SphereStrength = strength
+	}
+}
+
+// Count the words differing only by case as one, e.g. "iPhone", "Iphone" and "iphone". The merged word is drawn
+// with the casing of its most frequent variant.
+func MergeCaseVariants(do bool) Option {
+	return func(options *Options) {
+		options.MergeCaseVariants = do
+	}
+}
+
+// Record every placement attempt of Draw, to be read with Attempts or written as a Graphviz graph with
+// WriteAttemptsDOT when tuning the layout
+func RecordAttempts(do bool) Option {
+	return func(options *Options) {
+		options.RecordAttempts = do
+	}
+}
+
+// Only draw the words at these font sizes: the size computed for a word is rounded to the closest one, then kept
+// within FontMinSize and FontMaxSize
+func FontSizeSteps(sizes []float64) Option {
+	return func(options *Options) {
+		options.FontSizeSteps = sizes
+	}
+}
+
+// Dither the colors of EncodeGIF missing from its palette, e.g. on antialiased edges, instead of taking the closest
+// color of the palette
+func GIFDithering(do bool) Option {
+	return func(options *Options) {
+		options.GIFDithering = do
+	}
+}
+
+// Shrink the words longer than 4 letters, so that long words do not look more frequent than they are: the font size
+// of a word of n letters is multiplied by (4/n)^penalty. A penalty of 0.3 draws a 12 letter word at 72% of the size
+// of a 4 letter word with the same count.
+func LengthPenalty(penalty float64) Option {
+	return func(options *Options) {
+		options.LengthPenalty = penalty
+	}
+}
+
+// Measure and draw the words with the faces of the provider instead of FontFile and FallbackFonts. The outlines of
+// the glyphs are not known, so large words get their boxes from their pixels like icons, and WordStroke has no
+// effect.
+func WithFontProvider(provider FontProvider) Option {
+	return func(options *Options) {
+		options.FontProvider = provider
+	}
+}
+
+// Only place words at most width pixels away from the outline of the Region, so that the words trace the shape and
+// leave its inside empty. Without a Region, the words line the edges of the canvas. The words also line the masks
+// of MaskBoxes and MaskSVGPath.
+func OutlineBand(width float64) Option {
+	return func(options *Options) {
+		options.OutlineBand = width
+	}
+}
+
+// Lay the words out as a treemap instead of a spiral: the canvas is split into rectangles of areas proportional to
+// the counts, and each word is drawn at the center of its rectangle, shrunk to fit it. Masks and the Region are
+// ignored.
+func TreemapLayout(do bool) Option {
+	return func(options *Options) {
+		options.Treemap = do
+	}
+}
+
+// Give up on a word if finding its position on the spiral takes longer than d, so that a single word hard to place,
+// e.g. a huge word on a crowded canvas, cannot make Draw last. The word is skipped like a word without room.
+func WordTimeout(d time.Duration) Option {
+	return func(options *Options) {
+		options.WordTimeout = d
+	}
+}
+
+// Make the cloud symmetric across the vertical axis of the canvas: the words are placed in the left half only, then
+// each one is copied at the mirrored position in the right half. No word crosses the axis, so the center column stays
+// empty and no word overlaps its reflection. A word is only placed where its reflection also clears the masks, the
+// title and the watermark, and fits the Region and OutlineBand, even when they are not symmetric.
+func MirrorSymmetry(do bool) Option {
+	return func(options *Options) {
+		options.Mirror = do
+	}
+}
+
+// spiralCenter returns the center of the placement spiral. Important words are placed first, around it.
+func (o *Options) spiralCenter() (float64, float64) {
+	return float64(o.Width/2) - o.PlacementBias*float64(o.Width)/4,
+		float64(o.Height/2) - o.PlacementBias*float64(o.Height)/4
+}
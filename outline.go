@@ -0,0 +1,204 @@
+package wordclouds
+
+import (
+	"math"
+	"strings"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	// Number of horizontal strips the outline of a glyph is split into
+	outlineStrips = 4
+	// Number of segments a curve of a glyph outline is flattened into
+	curveSegments = 4
+	// Room left around the boxes of the outlines, for antialiasing and to keep words apart
+	outlineMargin = 5
+)
+
+// outlineBoxes returns boxes following the shape of the placed word, on the canvas. Each glyph is split into
+// horizontal strips covering the glyph outline within the strip, with the advances and kerning of the rendered text.
+func (w *Wordcloud) outlineBoxes(pw *placedWord) []*Box {
+	margin := outlineMargin + w.strokeWidth(pw.size)/2 + w.padding(pw.word)
+	boxes := make([]*Box, 0)
+	w.eachGlyph(pw, pw.x-w.barWidth(pw.bar, pw.size)/2, pw.y, func(buf *truetype.GlyphBuf, x float64, y float64) {
+		for _, b := range glyphStrips(buf) {
+			corners := rotatedCorners(pw.x, pw.y, x+b.Left-margin, y-b.Top-margin, x+b.Right+margin, y-b.Bottom+margin,
+				pw.angle)
+			boxes = append(boxes, cornersBox(corners))
+		}
+	})
+	if pw.bar > 0 {
+		left, top, width, height := w.barRect(pw, pw.x, pw.y)
+		corners := rotatedCorners(pw.x, pw.y, left-margin, top-margin, left+width+margin, top+height+margin, pw.angle)
+		boxes = append(boxes, cornersBox(corners))
+	}
+	return boxes
+}
+
+// eachGlyph loads the glyphs of the placed word in turn and calls f with the start of the baseline of each glyph,
+// before rotation, the text being centered on x, y like drawString does
+func (w *Wordcloud) eachGlyph(pw *placedWord, cx float64, cy float64, f func(buf *truetype.GlyphBuf, x float64,
+	y float64)) {
+	face := w.face(pw.text, pw.size)
+	dpi := w.opts.DPI
+	if dpi == 0 {
+		dpi = defaultDPI
+	}
+	scale := fixed.Int26_6(0.5 + pw.size*dpi*64/72)
+	fontHeight := float64(face.Metrics().Height) / 64
+
+	lines := strings.Split(pw.text, "\n")
+	height := fontHeight
+	if len(lines) > 1 {
+		height = (float64(len(lines)-1)*w.opts.LineSpacing + 1) * fontHeight
+	}
+	buf := &truetype.GlyphBuf{}
+	for i, line := range lines {
+		lineWidth := float64(font.MeasureString(face, line) >> 6)
+		dotX := fixed.I(0)
+		prev := rune(-1)
+		for _, r := range line {
+			if prev >= 0 {
+				dotX += face.Kern(prev, r)
+			}
+			prev = r
+			advance, _ := face.GlyphAdvance(r)
+			ft, err := w.fontFor(r)
+			if err != nil {
+				panic(err)
+			}
+			if err := buf.Load(ft, scale, ft.Index(r), w.opts.Hinting); err != nil {
+				panic(err)
+			}
+			x := cx - lineWidth/2 + float64(dotX)/64
+			y := cy - height/2 + float64(i)*fontHeight*w.opts.LineSpacing + fontHeight
+			f(buf, x, y)
+			dotX += advance
+		}
+	}
+}
+
+// glyphStrips returns the boxes covering the outline of the loaded glyph, relative to its origin, with y upwards
+func glyphStrips(buf *truetype.GlyphBuf) []Box {
+	if len(buf.Points) == 0 {
+		return nil
+	}
+	var segments [][2]point
+	start := 0
+	for _, end := range buf.Ends {
+		segments = appendContour(segments, buf.Points[start:end])
+		start = end
+	}
+
+	bottom, top := float64(buf.Bounds.Min.Y)/64, float64(buf.Bounds.Max.Y)/64
+	step := (top - bottom) / outlineStrips
+	strips := make([]Box, 0, outlineStrips)
+	for i := 0; i < outlineStrips; i++ {
+		y0, y1 := bottom+float64(i)*step, bottom+float64(i+1)*step
+		left, right := math.Inf(1), math.Inf(-1)
+		for _, s := range segments {
+			a, b := s[0], s[1]
+			if a.y >= y0 && a.y <= y1 {
+				left, right = math.Min(left, a.x), math.Max(right, a.x)
+			}
+			// Crossings of the segment with the limits of the strip
+			for _, ly := range []float64{y0, y1} {
+				if (a.y-ly)*(b.y-ly) < 0 {
+					x := a.x + (ly-a.y)*(b.x-a.x)/(b.y-a.y)
+					left, right = math.Min(left, x), math.Max(right, x)
+				}
+			}
+		}
+		if left <= right {
+			strips = append(strips, Box{y1, left, right, y0})
+		}
+	}
+	return strips
+}
+
+// appendContour appends the segments of a closed glyph contour, its quadratic curves flattened
+func appendContour(segments [][2]point, points []truetype.Point) [][2]point {
+	walkContour(points, func(a point, b point) {
+		segments = append(segments, [2]point{a, b})
+	}, func(a point, b point, c point) {
+		segments = appendCurve(segments, a, b, c)
+	})
+	return segments
+}
+
+// walkContour calls line for each straight segment of a closed glyph contour and curve for each quadratic curve,
+// with its control point, in order around the contour
+func walkContour(points []truetype.Point, line func(a point, b point), curve func(a point, b point, c point)) {
+	n := len(points)
+	if n == 0 {
+		return
+	}
+	toPoint := func(p truetype.Point) point {
+		return point{float64(p.X) / 64, float64(p.Y) / 64}
+	}
+	onCurve := func(p truetype.Point) bool {
+		return p.Flags&0x01 != 0
+	}
+
+	// Start on a point of the curve, implied between two control points if there is none
+	first := 0
+	for first < n && !onCurve(points[first]) {
+		first++
+	}
+	var start point
+	if first == n {
+		a, b := toPoint(points[0]), toPoint(points[1%n])
+		start = point{(a.x + b.x) / 2, (a.y + b.y) / 2}
+		first = 1 % n
+	} else {
+		start = toPoint(points[first])
+		first++
+	}
+
+	current := start
+	var control *point
+	for i := 0; i < n; i++ {
+		p := points[(first+i)%n]
+		q := toPoint(p)
+		if !onCurve(p) {
+			if control != nil {
+				// Two control points in a row imply a point of the curve between them
+				mid := point{(control.x + q.x) / 2, (control.y + q.y) / 2}
+				curve(current, *control, mid)
+				current = mid
+			}
+			control = &q
+			continue
+		}
+		if control != nil {
+			curve(current, *control, q)
+			control = nil
+		} else {
+			line(current, q)
+		}
+		current = q
+	}
+	if control != nil {
+		curve(current, *control, start)
+	} else if current != start {
+		line(current, start)
+	}
+}
+
+// appendCurve appends the quadratic curve from a to c with control point b, flattened into segments
+func appendCurve(segments [][2]point, a point, b point, c point) [][2]point {
+	prev := a
+	for i := 1; i <= curveSegments; i++ {
+		t := float64(i) / curveSegments
+		p := point{
+			(1-t)*(1-t)*a.x + 2*(1-t)*t*b.x + t*t*c.x,
+			(1-t)*(1-t)*a.y + 2*(1-t)*t*b.y + t*t*c.y,
+		}
+		segments = append(segments, [2]point{prev, p})
+		prev = p
+	}
+	return segments
+}
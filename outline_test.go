@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_OutlineBoxes(t *testing.T) {
+	words := loadTestWords(t)
+	words["multi\nline"] = 30
+	w := newTestCloud(t, words,
+		FontMaxSize(80),
+		FontMinSize(10),
+		Width(500),
+		Height(500),
+		AngleFromRadius(30),
+	)
+	w.Draw()
+	assert.Empty(t, w.VerifyNoOverlap())
+
+	// Every pixel drawn for a word is in one of its boxes
+	layers := w.Layers()
+	outlined := 0
+	for i, pw := range w.placed {
+		if pw.height <= 40 {
+			continue
+		}
+		outlined++
+		img := layers[i].Image
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+				if _, _, _, a := img.At(x, y).RGBA(); a == 0 {
+					continue
+				}
+				px, py := float64(x+layers[i].Offset.X)+0.5, float64(y+layers[i].Offset.Y)+0.5
+				in := false
+				for _, b := range pw.boxes {
+					if px >= b.Left && px <= b.Right && py >= b.Bottom && py <= b.Top {
+						in = true
+						break
+					}
+				}
+				if !in {
+					t.Fatalf("pixel %v, %v of %q is not in its boxes", px, py, pw.word)
+				}
+			}
+		}
+	}
+	assert.Greater(t, outlined, 3)
+}
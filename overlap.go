@@ -0,0 +1,57 @@
+package wordclouds
+
+import "math"
+
+// OverlapPair is a pair of placed words colliding with each other
+type OverlapPair struct {
+	// The words, in placement order
+	First  PlacedWord
+	Second PlacedWord
+	// The first two intersecting boxes found: one the first word occupies, one the second word was placed in
+	FirstBox  Box
+	SecondBox Box
+}
+
+// VerifyNoOverlap checks the words drawn by Draw against each other and returns the pairs of words that collide.
+// Each word is checked the way it was placed: the boxes it was tested with must not intersect the boxes the words
+// placed before it occupy, e.g. their precise boxes. An empty result means the layout has no collision. Words drawn
+// with NoCollision are expected to overlap.
+func (w *Wordcloud) VerifyNoOverlap() []OverlapPair {
+	var res []OverlapPair
+	var placed []PlacedWord
+	for j := range w.placed {
+		b := &w.placed[j]
+		tested := b.testedBoxes()
+		for i := 0; i < j; i++ {
+			if ab, bb := overlappingBoxes(w.placed[i].boxes, tested); ab != nil {
+				if placed == nil {
+					placed = w.PlacedWords()
+				}
+				res = append(res, OverlapPair{placed[i], placed[j], *ab, *bb})
+			}
+		}
+	}
+	return res
+}
+
+// testedBoxes returns the boxes the word was tested against the grid with
+func (pw *placedWord) testedBoxes() []*Box {
+	if pw.angle == 0 || pw.angle == 90 {
+		return []*Box{pw.box}
+	}
+	corners := rotatedCorners(pw.x, pw.y, pw.x-pw.width/2, pw.y-pw.height/2, pw.x+pw.width/2, pw.y+pw.height/2,
+		pw.angle)
+	return stripBoxes(corners, math.Min(pw.width, pw.height)/2)
+}
+
+// overlappingBoxes returns the first box of a and the first box of b found to intersect, or nil
+func overlappingBoxes(a []*Box, b []*Box) (*Box, *Box) {
+	for _, ab := range a {
+		for _, bb := range b {
+			if ab.overlaps(bb) {
+				return ab, bb
+			}
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_VerifyNoOverlap(t *testing.T) {
+	words := loadTestWords(t)
+	for _, angle := range []float64{0, 45} {
+		w := newTestCloud(t, words,
+			FontMaxSize(60),
+			FontMinSize(10),
+			AngleFromRadius(angle),
+		)
+		w.Draw()
+		assert.Empty(t, w.VerifyNoOverlap())
+	}
+
+	w := newTestCloud(t, words,
+		FontMaxSize(60),
+		FontMinSize(10),
+		NoCollision(true),
+	)
+	w.Draw()
+	overlaps := w.VerifyNoOverlap()
+	assert.NotEmpty(t, overlaps)
+	for _, o := range overlaps {
+		assert.NotEqual(t, o.First.Word, o.Second.Word)
+		assert.True(t, o.FirstBox.overlaps(&o.SecondBox))
+		assert.True(t, o.First.Box.overlaps(&o.FirstBox))
+	}
+}
@@ -0,0 +1,72 @@
+package wordclouds
+
+import "math"
+
+// OversizeMode tells what to do with the largest word when it does not fit the canvas at its size
+type OversizeMode int
+
+const (
+	// The largest word is skipped like any word without room
+	SkipOversize OversizeMode = iota
+	// The largest word is shrunk until it fits the canvas, the other words keep their size
+	ShrinkOversize
+	// All the words are scaled down by the same factor until the largest one fits the canvas, keeping their
+	// proportions down to FontMinSize
+	ScaleAllOversize
+)
+
+const (
+	// Factor the size of the largest word is reduced by until it fits, once estimated from its measure
+	oversizeStep = 0.98
+	// Room left around the largest word, for the first positions of the spiral that are a pixel off the center
+	oversizeSlack = 4
+)
+
+// fitLargestWord shrinks the largest word, or all the words with ScaleAllOversize, until the largest one fits the
+// empty canvas. Returns the factor applied to the sizes.
+func (w *Wordcloud) fitLargestWord() float64 {
+	if w.opts.Oversize == SkipOversize || len(w.sortedWordList) == 0 {
+		return 1
+	}
+	largest := 0
+	for i, wc := range w.sortedWordList {
+		if wc.size > w.sortedWordList[largest].size {
+			largest = i
+		}
+	}
+
+	wc := w.sortedWordList[largest]
+	width, height := w.measureWord(w.dc, wc, w.text(wc))
+	if w.fitsCanvas(width+5+oversizeSlack, height+5+oversizeSlack) {
+		return 1
+	}
+	// The text scales with the font size, the margins do not
+	roomWidth := w.width - 2*w.opts.EdgeMargin - 5 - oversizeSlack
+	roomHeight := w.height - 2*w.opts.EdgeMargin - 5 - oversizeSlack
+	factor := math.Min(roomWidth/width, roomHeight/height)
+	if w.opts.RotateToFit {
+		factor = math.Max(factor, math.Min(roomWidth/height, roomHeight/width))
+	}
+	if w.opts.RadialLayout {
+		factor = math.Hypot(roomWidth, roomHeight) / math.Max(width, height)
+	}
+	minSize := float64(w.opts.FontMinSize)
+	size := wc.size
+	for {
+		wc.size = w.snapDown(math.Max(size*factor, minSize))
+		width, height = w.measureWord(w.dc, wc, w.text(wc))
+		if wc.size <= minSize || w.fitsCanvas(width+5+oversizeSlack, height+5+oversizeSlack) {
+			break
+		}
+		factor *= oversizeStep
+	}
+
+	if w.opts.Oversize == ShrinkOversize {
+		w.sortedWordList[largest].size = wc.size
+		return 1
+	}
+	for i := range w.sortedWordList {
+		w.sortedWordList[i].size = w.snapDown(math.Max(w.sortedWordList[i].size*factor, minSize))
+	}
+	return factor
+}
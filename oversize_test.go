@@ -0,0 +1,43 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Oversize(t *testing.T) {
+	words := map[string]int{"extraordinarily": 100, "half": 50, "quarter": 25}
+	sizes := func(mode OversizeMode) (map[string]float64, *Wordcloud) {
+		w := newTestCloud(t, words,
+			FontMaxSize(120),
+			FontMinSize(10),
+			Oversize(mode),
+		)
+		w.Draw()
+		res := make(map[string]float64)
+		for _, pw := range w.placed {
+			res[pw.word] = pw.size
+		}
+		return res, w
+	}
+
+	skip, _ := sizes(SkipOversize)
+	assert.Equal(t, map[string]float64{"half": 60, "quarter": 30}, skip)
+
+	shrink, _ := sizes(ShrinkOversize)
+	assert.Len(t, shrink, 3)
+	assert.Less(t, shrink["extraordinarily"], 120.0)
+	assert.Equal(t, 60.0, shrink["half"])
+	assert.Equal(t, 30.0, shrink["quarter"])
+
+	// The proportions are kept and the largest word takes most of the width
+	scaled, w := sizes(ScaleAllOversize)
+	assert.Len(t, scaled, 3)
+	assert.Equal(t, shrink["extraordinarily"], scaled["extraordinarily"])
+	assert.InDelta(t, scaled["extraordinarily"]/2, scaled["half"], 1e-9)
+	assert.InDelta(t, scaled["extraordinarily"]/4, scaled["quarter"], 1e-9)
+	assert.Greater(t, w.placed[0].box.Right-w.placed[0].box.Left, 350.0)
+	width, _ := w.MeasureWord("half", 50)
+	assert.Equal(t, w.placed[1].width, width)
+}
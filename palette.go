@@ -0,0 +1,36 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// palette returns the colors the cloud is drawn with: the background first, then the word colors
+func (w *Wordcloud) palette() color.Palette {
+	p := color.Palette{w.opts.BackgroundColor}
+	for _, c := range w.opts.Colors {
+		if !paletteContains(p, c) {
+			p = append(p, c)
+		}
+	}
+	return p
+}
+
+func paletteContains(p color.Palette, c color.Color) bool {
+	r, g, b, a := c.RGBA()
+	for _, pc := range p {
+		pr, pg, pb, pa := pc.RGBA()
+		if r == pr && g == pg && b == pb && a == pa {
+			return true
+		}
+	}
+	return false
+}
+
+// quantize maps every pixel of img to the closest color of the palette
+func quantize(img image.Image, p color.Palette) *image.Paletted {
+	dst := image.NewPaletted(img.Bounds(), p)
+	draw.Draw(dst, dst.Bounds(), img, img.Bounds().Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,60 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_QuantizePalette(t *testing.T) {
+	colors := []color.Color{
+		color.RGBA{0x59, 0x3a, 0xee, 0xff},
+		color.RGBA{0x65, 0xCD, 0xFA, 0xff},
+		color.RGBA{0x70, 0xD6, 0xBF, 0xff},
+	}
+	w := NewWordcloud(map[string]int{"important": 42, "noteworthy": 30, "meh": 3, "golang": 12},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(100),
+		Colors(colors),
+		Width(512),
+		Height(512),
+		QuantizePalette(true),
+	)
+
+	img := w.Draw()
+	paletted, ok := img.(*image.Paletted)
+	assert.True(t, ok)
+
+	distinct := make(map[uint8]bool)
+	for _, idx := range paletted.Pix {
+		distinct[idx] = true
+	}
+	assert.LessOrEqual(t, len(distinct), len(colors)+1)
+	assert.Greater(t, len(distinct), 1)
+}
+
+func TestWordcloud_ColorModel(t *testing.T) {
+	w := NewWordcloud(map[string]int{"gray": 10},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(60),
+		Colors([]color.Color{color.RGBA{0xff, 0, 0, 0xff}}),
+		BackgroundColor(color.White),
+		Width(300),
+		Height(200),
+		ColorModel(color.GrayModel),
+	)
+
+	img := w.Draw()
+	gray, ok := img.(*image.Gray)
+	assert.True(t, ok)
+
+	values := make(map[uint8]bool)
+	for i := range gray.Pix {
+		values[gray.Pix[i]] = true
+	}
+	assert.True(t, values[0xff], "background")
+	// Luminance of pure red
+	assert.True(t, values[color.GrayModel.Convert(color.RGBA{0xff, 0, 0, 0xff}).(color.Gray).Y], "word")
+}
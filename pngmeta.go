@@ -0,0 +1,119 @@
+package wordclouds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// Keyword of the png chunk holding the word list
+const pngWordListKeyword = "wordlist"
+
+// Length of the png signature followed by the IHDR chunk, always first in a png file
+const pngHeaderLength = 8 + 8 + 13 + 4
+
+// Largest text chunk read by ReadPNGWordList, with its checksum
+const maxPNGTextLength = 16 << 20
+
+// pngChunkWriter inserts a chunk right after the header of the png written through it
+type pngChunkWriter struct {
+	out     io.Writer
+	chunk   []byte
+	written int
+}
+
+func (p *pngChunkWriter) Write(b []byte) (int, error) {
+	n := 0
+	if p.written < pngHeaderLength {
+		head := b
+		if len(head) > pngHeaderLength-p.written {
+			head = head[:pngHeaderLength-p.written]
+		}
+		m, err := p.out.Write(head)
+		n += m
+		p.written += m
+		if err != nil {
+			return n, err
+		}
+		if p.written == pngHeaderLength {
+			if _, err := p.out.Write(p.chunk); err != nil {
+				return n, err
+			}
+		}
+		b = b[len(head):]
+	}
+	m, err := p.out.Write(b)
+	p.written += m
+	return n + m, err
+}
+
+// pngChunk builds a png chunk: length, type, data and checksum of type and data
+func pngChunk(typ string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], typ)
+	chunk = append(chunk, data...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	return append(chunk, crc...)
+}
+
+// pngWriter returns the writer to encode the png of the cloud to, adding the word list when EmbedWordList is set
+func (w *Wordcloud) pngWriter(out io.Writer) (io.Writer, error) {
+	if !w.opts.EmbedWordList {
+		return out, nil
+	}
+	words, err := json.Marshal(w.wordList)
+	if err != nil {
+		return nil, err
+	}
+	// Uncompressed international text: keyword, compression flag and method, empty language and translated keyword
+	data := append([]byte(pngWordListKeyword), 0, 0, 0, 0, 0)
+	return &pngChunkWriter{out: out, chunk: pngChunk("iTXt", append(data, words...))}, nil
+}
+
+// ReadPNGWordList reads the word list embedded in a png encoded with the EmbedWordList option
+func ReadPNGWordList(r io.Reader) (map[string]int, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if string(header) != "\x89PNG\r\n\x1a\n" {
+		return nil, errors.New("not a png")
+	}
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, err
+		}
+		// Data and checksum
+		length := int64(binary.BigEndian.Uint32(header)) + 4
+		typ := string(header[4:])
+		if typ != "iTXt" {
+			if _, err := io.CopyN(io.Discard, r, length); err != nil {
+				return nil, err
+			}
+			if typ == "IEND" {
+				return nil, errors.New("no word list in png")
+			}
+			continue
+		}
+		if length > maxPNGTextLength {
+			return nil, errors.New("text chunk too large")
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		data = data[:len(data)-4]
+		prefix := append([]byte(pngWordListKeyword), 0, 0, 0, 0, 0)
+		if !bytes.HasPrefix(data, prefix) {
+			continue
+		}
+		words := make(map[string]int)
+		err := json.Unmarshal(data[len(prefix):], &words)
+		return words, err
+	}
+}
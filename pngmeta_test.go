@@ -0,0 +1,63 @@
+package wordclouds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_EmbedWordList(t *testing.T) {
+	words := map[string]int{"café": 10, "tea": 5, "water": 3}
+	w := newTestCloud(t, words,
+		Width(200),
+		Height(200),
+		EmbedWordList(true),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, w.DrawPNG(&buf))
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, img.Bounds().Dx())
+	embedded, err := ReadPNGWordList(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, words, embedded)
+
+	// The cloud is drawn once
+	placed := w.placed
+	buf.Reset()
+	assert.NoError(t, w.DrawPNG(&buf))
+	assert.Equal(t, placed, w.placed)
+
+	buf.Reset()
+	assert.NoError(t, w.Encode(&buf, "png", 0))
+	embedded, err = ReadPNGWordList(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, words, embedded)
+
+	w = newTestCloud(t, words, Width(200), Height(200))
+	buf.Reset()
+	assert.NoError(t, w.DrawPNG(&buf))
+	_, err = ReadPNGWordList(&buf)
+	assert.Error(t, err)
+}
+
+func TestReadPNGWordList_ChunkLength(t *testing.T) {
+	signature := []byte("\x89PNG\r\n\x1a\n")
+	chunk := func(length uint32, typ string) []byte {
+		header := make([]byte, 8)
+		binary.BigEndian.PutUint32(header, length)
+		copy(header[4:], typ)
+		return header
+	}
+
+	// Chunks declaring more data than there is are not allocated
+	_, err := ReadPNGWordList(bytes.NewReader(append(signature, chunk(0xffffffff, "iTXt")...)))
+	assert.EqualError(t, err, "text chunk too large")
+	_, err = ReadPNGWordList(bytes.NewReader(append(signature, chunk(0xffffffff, "IDAT")...)))
+	assert.Equal(t, io.EOF, err)
+}
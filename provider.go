@@ -0,0 +1,59 @@
+package wordclouds
+
+import (
+	"os"
+	"sync"
+
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+)
+
+// FontProvider gives the font faces the words are measured and drawn with, in place of FontFile
+type FontProvider interface {
+	// Face returns the face of the font at the given size, in points
+	Face(size float64) (font.Face, error)
+}
+
+// FileFontProvider is a FontProvider reading a TrueType font file, parsed on the first call to Face
+type FileFontProvider struct {
+	Path string
+	// Resolution of the faces in dots per inch, 72 if 0
+	DPI     float64
+	Hinting font.Hinting
+
+	once sync.Once
+	font *truetype.Font
+	err  error
+}
+
+// Face returns the face of the font file at the given size
+func (p *FileFontProvider) Face(size float64) (font.Face, error) {
+	p.once.Do(func() {
+		var b []byte
+		b, p.err = os.ReadFile(p.Path)
+		if p.err != nil {
+			return
+		}
+		p.font, p.err = truetype.Parse(b)
+	})
+	if p.err != nil {
+		return nil, p.err
+	}
+	return truetype.NewFace(p.font, &truetype.Options{Size: size, DPI: p.DPI, Hinting: p.Hinting}), nil
+}
+
+// providedFace returns the face of the FontProvider at the given size, asking the provider once per size
+func (w *Wordcloud) providedFace(size float64) (font.Face, error) {
+	w.fontsMu.Lock()
+	f, ok := w.providedFaces[size]
+	if !ok {
+		f = &fontFace{}
+		w.providedFaces[size] = f
+	}
+	w.fontsMu.Unlock()
+
+	f.once.Do(func() {
+		f.face, f.err = w.opts.FontProvider.Face(size)
+	})
+	return f.face, f.err
+}
@@ -0,0 +1,95 @@
+package wordclouds
+
+import (
+	"image/color"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+)
+
+// sizesProvider records the sizes its faces are asked at
+type sizesProvider struct {
+	mu    sync.Mutex
+	sizes []float64
+}
+
+func (p *sizesProvider) Face(size float64) (font.Face, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sizes = append(p.sizes, size)
+	return basicfont.Face7x13, nil
+}
+
+func TestWordcloud_FontProvider(t *testing.T) {
+	provider := &sizesProvider{}
+	w := NewWordcloud(map[string]int{"fixed": 10, "width": 5},
+		FontMaxSize(60),
+		Colors([]color.Color{color.Black}),
+		Width(200),
+		Height(200),
+		WithFontProvider(provider),
+	)
+
+	// The fixed font ignores the size: 7 pixels per character
+	width, height := w.MeasureWord("fixed", 10)
+	assert.Equal(t, 5.0*7+5, width)
+	assert.Equal(t, 13.0+5, height)
+	img := w.Draw()
+	assert.Len(t, w.placed, 2)
+	assert.ElementsMatch(t, []float64{60, 30}, provider.sizes)
+
+	inked := 0
+	for _, pw := range w.placed {
+		for x := int(pw.box.Left); x < int(pw.box.Right); x++ {
+			for y := int(pw.box.Bottom); y < int(pw.box.Top); y++ {
+				if r, _, _, _ := img.At(x, y).RGBA(); r < 0x8000 {
+					inked++
+				}
+			}
+		}
+	}
+	assert.Greater(t, inked, 50)
+
+	// The file provider gives the faces of the font file
+	file := &FileFontProvider{Path: "testdata/Roboto-Regular.ttf"}
+	w = NewWordcloud(map[string]int{"roboto": 10}, FontFile("testdata/Roboto-Regular.ttf"), FontMaxSize(40))
+	expected, _ := w.MeasureWord("roboto", 10)
+	w = NewWordcloud(map[string]int{"roboto": 10}, WithFontProvider(file), FontMaxSize(40))
+	width, _ = w.MeasureWord("roboto", 10)
+	assert.Equal(t, expected, width)
+	_, err := (&FileFontProvider{Path: "missing.ttf"}).Face(10)
+	assert.Error(t, err)
+}
+
+func TestWordcloud_FontProviderPaddingAndStroke(t *testing.T) {
+	file := &FileFontProvider{Path: "testdata/Roboto-Regular.ttf"}
+	// Left of the precise boxes of the word from the left of its box, which grows with the padding
+	inset := func(opts ...Option) float64 {
+		opts = append([]Option{
+			WithFontProvider(file),
+			FontMaxSize(80),
+			Colors([]color.Color{color.Black}),
+			Width(400),
+			Height(400),
+		}, opts...)
+		w := NewWordcloud(map[string]int{"Padded": 10}, opts...)
+		w.Draw()
+		assert.Len(t, w.placed, 1)
+		// The faces come from the provider only
+		assert.Empty(t, w.fonts)
+		pw := w.placed[0]
+		left := math.Inf(1)
+		for _, b := range pw.boxes {
+			left = math.Min(left, b.Left)
+		}
+		return left - pw.box.Left
+	}
+	plain := inset()
+	assert.InDelta(t, plain, inset(WordPadding(map[string]float64{"padded": 10})), 1)
+	// Words of a provider are not stroked, nor measured with a stroke
+	assert.Equal(t, plain, inset(WordStroke(func(size float64) float64 { return 10 }, nil)))
+}
@@ -0,0 +1,90 @@
+package wordclouds
+
+import (
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// pointAngle returns the rotation in degrees of a word placed at x, y, on the circle of the given radius
+func (w *Wordcloud) pointAngle(x float64, y float64, radius float64) float64 {
+	if w.opts.RadialLayout {
+		return w.radialAngle(x, y)
+	}
+	if w.opts.AngleFromRadius != 0 {
+		// Words in the corners get the full angle
+		return w.opts.AngleFromRadius * math.Min(radius/(math.Hypot(w.width, w.height)/2), 1)
+	}
+	return 0
+}
+
+// radialAngle returns the rotation in degrees of a word centered on x, y so that it follows the radius of the canvas
+// center. Words left of the center are flipped so that they never read upside down.
+func (w *Wordcloud) radialAngle(x float64, y float64) float64 {
+	angle := gg.Degrees(math.Atan2(w.height/2-y, x-w.width/2))
+	if angle > 90 {
+		angle -= 180
+	} else if angle < -90 {
+		angle += 180
+	}
+	return angle
+}
+
+// rotatedCorners returns the corners of the rectangle spanning left to right and top to bottom around x, y, once
+// rotated about x, y by angle degrees, counterclockwise
+func rotatedCorners(x float64, y float64, left float64, top float64, right float64, bottom float64,
+	angle float64) [4]point {
+	// Image coordinates: y grows downwards
+	cos, sin := math.Cos(gg.Radians(-angle)), math.Sin(gg.Radians(-angle))
+	corners := [4]point{{left, top}, {right, top}, {right, bottom}, {left, bottom}}
+	for i, c := range corners {
+		dx, dy := c.x-x, c.y-y
+		corners[i] = point{x + dx*cos - dy*sin, y + dx*sin + dy*cos}
+	}
+	return corners
+}
+
+// cornersBox returns the smallest box containing the corners
+func cornersBox(corners [4]point) *Box {
+	b := &Box{corners[0].y, corners[0].x, corners[0].x, corners[0].y}
+	for _, c := range corners[1:] {
+		b.Top = math.Max(b.Top, c.y)
+		b.Bottom = math.Min(b.Bottom, c.y)
+		b.Left = math.Min(b.Left, c.x)
+		b.Right = math.Max(b.Right, c.x)
+	}
+	return b
+}
+
+// stripBoxes covers the rotated rectangle with horizontal strips at most stripHeight high, each spanning the width of
+// the rectangle within the strip
+func stripBoxes(corners [4]point, stripHeight float64) []*Box {
+	bb := cornersBox(corners)
+	n := int(math.Max(math.Ceil((bb.Top-bb.Bottom)/stripHeight), 1))
+	step := (bb.Top - bb.Bottom) / float64(n)
+	boxes := make([]*Box, 0, n)
+	for i := 0; i < n; i++ {
+		y0, y1 := bb.Bottom+float64(i)*step, bb.Bottom+float64(i+1)*step
+		left, right := math.Inf(1), math.Inf(-1)
+		extend := func(x float64) {
+			left = math.Min(left, x)
+			right = math.Max(right, x)
+		}
+		for j, a := range corners {
+			if a.y >= y0 && a.y <= y1 {
+				extend(a.x)
+			}
+			// Crossings of the edge with the limits of the strip
+			b := corners[(j+1)%4]
+			for _, ly := range []float64{y0, y1} {
+				if (a.y-ly)*(b.y-ly) < 0 {
+					extend(a.x + (ly-a.y)*(b.x-a.x)/(b.y-a.y))
+				}
+			}
+		}
+		if left <= right {
+			boxes = append(boxes, &Box{y1, left, right, y0})
+		}
+	}
+	return boxes
+}
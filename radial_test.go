@@ -0,0 +1,43 @@
+package wordclouds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_RadialLayout(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		RadialLayout(true),
+	)
+	w.Draw()
+
+	rotated := 0
+	for _, pw := range w.placed {
+		assert.InDelta(t, w.radialAngle(pw.x, pw.y), pw.angle, 1e-9, pw.word)
+		assert.GreaterOrEqual(t, pw.angle, -90.0)
+		assert.LessOrEqual(t, pw.angle, 90.0)
+		if pw.angle != 0 {
+			rotated++
+		}
+	}
+	assert.Greater(t, rotated, len(w.placed)/2)
+}
+
+func TestWordcloud_AngleFromRadius(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		AngleFromRadius(20),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		distance := math.Hypot(pw.x-200, pw.y-200)
+		assert.InDelta(t, 20*distance/math.Hypot(200, 200), pw.angle, 1e-6, pw.word)
+	}
+}
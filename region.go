@@ -0,0 +1,112 @@
+package wordclouds
+
+import "math"
+
+// Point is a position on the canvas
+type Point struct {
+	X float64
+	Y float64
+}
+
+// fits tells whether the box is on the canvas, within the edge margin and the region if any, near the outline with
+// OutlineBand and, with MirrorSymmetry, in the left half with its reflection fitting too
+func (w *Wordcloud) fits(b *Box) bool {
+	if w.opts.Mirror {
+		// The right half is left to the reflections of the words
+		return b.Right < w.width/2 && w.fitsShape(b) && w.fitsShape(w.mirrorBox(b))
+	}
+	return w.fitsShape(b)
+}
+
+// fitsShape tells whether the box is on the canvas, within the edge margin and the region if any, and near the
+// outline with OutlineBand
+func (w *Wordcloud) fitsShape(b *Box) bool {
+	if !b.fits(w.width, w.height, w.opts.EdgeMargin) {
+		return false
+	}
+	if len(w.opts.Region) >= 3 && !inPolygon(b, w.opts.Region) {
+		return false
+	}
+	return w.opts.OutlineBand <= 0 || w.nearOutline(b)
+}
+
+// nearOutline tells whether the box is at most OutlineBand away from the outline of the region, or of the canvas
+// without a region, or from a mask
+func (w *Wordcloud) nearOutline(b *Box) bool {
+	outline := w.opts.Region
+	if len(outline) < 3 {
+		m := w.opts.EdgeMargin
+		outline = []Point{{m, m}, {w.width - m, m}, {w.width - m, w.height - m}, {m, w.height - m}}
+	}
+	band := w.opts.OutlineBand
+	grown := &Box{b.Top + band, b.Left - band, b.Right + band, b.Bottom - band}
+	for i, p := range outline {
+		if segmentCrossesBox(p, outline[(i+1)%len(outline)], grown) {
+			return true
+		}
+	}
+	// Words cannot overlap the masks, so a word close to a mask is close to its outline
+	for _, m := range w.opts.Mask {
+		if m.overlaps(grown) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPolygon tells whether the box is inside the polygon: one of its corners is inside and no edge of the polygon
+// crosses it
+func inPolygon(b *Box, polygon []Point) bool {
+	if !pointInPolygon(b.Left, b.Bottom, polygon) {
+		return false
+	}
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		if segmentCrossesBox(p, q, b) {
+			return false
+		}
+	}
+	return true
+}
+
+// pointInPolygon casts a ray from x, y and counts the edges it crosses
+func pointInPolygon(x float64, y float64, polygon []Point) bool {
+	inside := false
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		if (p.Y > y) != (q.Y > y) && x < p.X+(y-p.Y)*(q.X-p.X)/(q.Y-p.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// segmentCrossesBox clips the segment to the box and tells whether anything is left
+func segmentCrossesBox(p Point, q Point, b *Box) bool {
+	dx, dy := q.X-p.X, q.Y-p.Y
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-dx, p.X - b.Left},
+		{dx, b.Right - p.X},
+		{-dy, p.Y - b.Bottom},
+		{dy, b.Top - p.Y},
+	} {
+		d, dist := edge[0], edge[1]
+		if d == 0 {
+			if dist < 0 {
+				return false
+			}
+			continue
+		}
+		t := dist / d
+		if d < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+		if t0 > t1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Region(t *testing.T) {
+	triangle := []Point{{200, 20}, {380, 380}, {20, 380}}
+	for _, random := range []bool{false, true} {
+		w := newTestCloud(t, nil,
+			FontMaxSize(40),
+			FontMinSize(10),
+			RandomPlacement(random),
+			Region(triangle),
+		)
+		w.Draw()
+
+		assert.NotEmpty(t, w.placed)
+		for _, pw := range w.placed {
+			for _, c := range []Point{
+				{pw.box.Left, pw.box.Top},
+				{pw.box.Right, pw.box.Top},
+				{pw.box.Right, pw.box.Bottom},
+				{pw.box.Left, pw.box.Bottom},
+			} {
+				assert.True(t, pointInPolygon(c.X, c.Y, triangle), pw.word)
+			}
+		}
+	}
+}
+
+func TestWordcloud_OutlineBand(t *testing.T) {
+	square := []Point{{50, 50}, {350, 50}, {350, 350}, {50, 350}}
+	w := newTestCloud(t, nil,
+		FontMaxSize(30),
+		FontMinSize(10),
+		Region(square),
+		OutlineBand(40),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		assert.True(t, pointInPolygon(pw.bounds.Left, pw.bounds.Bottom, square), pw.word)
+		assert.True(t, pointInPolygon(pw.bounds.Right, pw.bounds.Top, square), pw.word)
+		inside := pw.bounds.Left > 90 && pw.bounds.Right < 310 && pw.bounds.Bottom > 90 && pw.bounds.Top < 310
+		assert.False(t, inside, pw.word)
+	}
+}
+
+func TestWordcloud_OutlineBandMask(t *testing.T) {
+	mask := &Box{250, 150, 250, 150}
+	w := newTestCloud(t, nil,
+		FontMaxSize(30),
+		FontMinSize(10),
+		MaskBoxes([]*Box{mask}),
+		OutlineBand(40),
+	)
+	w.Draw()
+
+	nearMask := 0
+	for _, pw := range w.placed {
+		b := pw.bounds
+		grown := &Box{b.Top + 40, b.Left - 40, b.Right + 40, b.Bottom - 40}
+		if grown.overlaps(mask) {
+			nearMask++
+			continue
+		}
+		nearEdge := b.Left < 40 || b.Right > 360 || b.Bottom < 40 || b.Top > 360
+		assert.True(t, nearEdge, pw.word)
+	}
+	assert.Greater(t, nearMask, 0)
+}
@@ -0,0 +1,71 @@
+package wordclouds
+
+import (
+	"image/color"
+	"math"
+)
+
+// Relation links two words of the cloud
+type Relation struct {
+	From string
+	To   string
+}
+
+// RelationStyle is the style of the lines drawn between related words. Lines are black and 1 pixel wide by default.
+type RelationStyle struct {
+	Color color.Color
+	Width float64
+}
+
+// boxExit returns how far along the segment of direction dx, dy starting at the center of the box it leaves the box,
+// as a fraction of the segment
+func boxExit(b *Box, dx float64, dy float64) float64 {
+	t := math.Inf(1)
+	if dx != 0 {
+		t = math.Min(t, b.w()/2/math.Abs(dx))
+	}
+	if dy != 0 {
+		t = math.Min(t, b.h()/2/math.Abs(dy))
+	}
+	return t
+}
+
+// drawRelations draws a straight line between each pair of related words. Lines go from box edge to box edge so
+// they do not cross the words they link. Relations with a word that was not placed are ignored.
+func (w *Wordcloud) drawRelations() {
+	placed := make(map[string]*placedWord)
+	for i := range w.placed {
+		if _, ok := placed[w.placed[i].word]; !ok {
+			placed[w.placed[i].word] = &w.placed[i]
+		}
+	}
+
+	style := w.opts.RelationStyle
+	if style.Color == nil {
+		style.Color = color.Black
+	}
+	if style.Width <= 0 {
+		style.Width = 1
+	}
+	w.dc.SetColor(style.Color)
+	w.dc.SetLineWidth(style.Width)
+	for _, r := range w.opts.Relations {
+		from, ok := placed[r.From]
+		if !ok {
+			continue
+		}
+		to, ok := placed[r.To]
+		if !ok {
+			continue
+		}
+		dx, dy := to.x-from.x, to.y-from.y
+		start := boxExit(from.box, dx, dy)
+		end := 1 - boxExit(to.box, dx, dy)
+		if start >= end {
+			// The boxes touch, there is nothing to draw between them
+			continue
+		}
+		w.dc.DrawLine(from.x+start*dx, from.y+start*dy, from.x+end*dx, from.y+end*dy)
+		w.dc.Stroke()
+	}
+}
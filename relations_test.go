@@ -0,0 +1,36 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Relations(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	words := map[string]int{"alpha": 10, "beta": 9, "gamma": 8, "delta": 7}
+	w := newTestCloud(t, words,
+		FontMaxSize(30),
+		Relations([]Relation{{"alpha", "delta"}, {"alpha", "missing"}}, RelationStyle{Color: red, Width: 3}),
+	)
+	img := w.Draw()
+
+	var from, to *placedWord
+	for i := range w.placed {
+		switch w.placed[i].word {
+		case "alpha":
+			from = &w.placed[i]
+		case "delta":
+			to = &w.placed[i]
+		}
+	}
+	assert.NotNil(t, from)
+	assert.NotNil(t, to)
+	// The middle of the line between the boxes of the words is drawn
+	dx, dy := to.x-from.x, to.y-from.y
+	start, end := boxExit(from.box, dx, dy), 1-boxExit(to.box, dx, dy)
+	assert.Less(t, start, end)
+	middle := (start + end) / 2
+	assert.Equal(t, color.Color(red), img.At(int(from.x+middle*dx), int(from.y+middle*dy)))
+}
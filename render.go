@@ -0,0 +1,180 @@
+package wordclouds
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"strings"
+
+	"github.com/fogleman/gg"
+)
+
+// measureString returns the size of the text with the current font of dc. Lines of a multi-line text are stacked,
+// so the width is the one of the widest line and the height grows with the line spacing.
+func (w *Wordcloud) measureString(dc *gg.Context, s string) (width float64, height float64) {
+	if !strings.Contains(s, "\n") {
+		return dc.MeasureString(s)
+	}
+	return dc.MeasureMultilineString(s, w.opts.LineSpacing)
+}
+
+// measureWord returns the size of a word, drawn as text with the font set on dc or as its icon
+func (w *Wordcloud) measureWord(dc *gg.Context, wc wordCount, text string) (width float64, height float64) {
+	if icon := w.icon(wc.word); icon != nil {
+		width, height = iconSize(icon, wc.size)
+	} else {
+		dc.SetFontFace(w.face(text, wc.size))
+		width, height = w.measureString(dc, text)
+		height *= w.opts.LineHeightFactor
+		// The stroke is centered on the outlines of the glyphs
+		stroke := w.strokeWidth(wc.size)
+		width, height = width+stroke, height+stroke
+	}
+	if length := w.barLength(wc); length > 0 {
+		width += w.barWidth(length, wc.size)
+		height = math.Max(height, w.opts.Bar.Thickness*w.pixelSize(wc.size))
+	}
+	return width, height
+}
+
+// drawString draws the text centered on x, y. Lines of a multi-line text are centered horizontally.
+func (w *Wordcloud) drawString(dc *gg.Context, s string, x float64, y float64) {
+	lines := strings.Split(s, "\n")
+	if len(lines) == 1 {
+		dc.DrawStringAnchored(s, x, y, 0.5, 0.5)
+		return
+	}
+	fontHeight := dc.FontHeight()
+	_, height := w.measureString(dc, s)
+	top := y - height/2
+	for i, line := range lines {
+		dc.DrawStringAnchored(line, x, top+float64(i)*fontHeight*w.opts.LineSpacing+fontHeight/2, 0.5, 0.5)
+	}
+}
+
+// renderWord draws a placed word on dc, shifted by -dx, -dy
+func (w *Wordcloud) renderWord(dc *gg.Context, pw *placedWord, dx float64, dy float64) {
+	dc.SetColor(pw.color)
+	icon := w.icon(pw.word)
+	if icon == nil {
+		dc.SetFontFace(w.face(pw.text, pw.size))
+	}
+	if pw.angle != 0 {
+		dc.Push()
+		defer dc.Pop()
+		dc.RotateAbout(gg.Radians(-pw.angle), pw.x-dx, pw.y-dy)
+	}
+	if c := w.chipColor(pw.word); c != nil {
+		w.drawChip(dc, pw, c, dx, dy)
+		dc.SetColor(pw.color)
+	}
+	w.drawBar(dc, pw, pw.x-dx, pw.y-dy)
+	// The word is on the left of its bar
+	x := pw.x - dx - w.barWidth(pw.bar, pw.size)/2
+	if icon != nil {
+		drawIcon(dc, icon, pw.size, x, pw.y-dy)
+		return
+	}
+	w.strokeText(dc, pw, x, pw.y-dy)
+	w.drawString(dc, pw.text, x, pw.y-dy)
+}
+
+// outline strokes the boxes on the canvas
+func (w *Wordcloud) outline(boxes []*Box) {
+	for _, b := range boxes {
+		w.dc.DrawRectangle(b.x(), b.y(), b.w(), b.h())
+		w.dc.Stroke()
+	}
+}
+
+// snapshot returns a copy of the canvas
+func (w *Wordcloud) snapshot() image.Image {
+	src := w.dc.Image()
+	img := image.NewRGBA(src.Bounds())
+	draw.Draw(img, img.Bounds(), src, src.Bounds().Min, draw.Src)
+	return img
+}
+
+// redrawWords draws the placed words where they are now over base, the canvas before any word was drawn
+func (w *Wordcloud) redrawWords(base image.Image) {
+	draw.Draw(w.dc.Image().(draw.Image), base.Bounds(), base, base.Bounds().Min, draw.Src)
+	for i := range w.placed {
+		pw := &w.placed[i]
+		w.renderWord(w.dc, pw, 0, 0)
+		if pw.outlined {
+			w.outline(pw.boxes)
+		}
+	}
+}
+
+// WordLayer is a placed word rendered alone on a transparent image
+type WordLayer struct {
+	Word  string
+	Image image.Image
+	// Position of the top left corner of the image on the canvas
+	Offset image.Point
+}
+
+// Layers renders each placed word on its own transparent image, cropped to the word. Drawing the layers at their
+// offset over the background gives back the cloud. Call it after Draw.
+func (w *Wordcloud) Layers() []WordLayer {
+	layers := make([]WordLayer, 0, len(w.placed))
+	for i := range w.placed {
+		pw := &w.placed[i]
+		left := int(math.Floor(pw.bounds.Left))
+		bottom := int(math.Floor(pw.bounds.Bottom))
+		width := int(math.Ceil(pw.bounds.Right)) - left
+		height := int(math.Ceil(pw.bounds.Top)) - bottom
+
+		dc := gg.NewContext(width, height)
+		w.renderWord(dc, pw, float64(left), float64(bottom))
+		layers = append(layers, WordLayer{
+			Word:   pw.word,
+			Image:  dc.Image(),
+			Offset: image.Pt(left, bottom),
+		})
+	}
+	return layers
+}
+
+// MeasureWord returns the size of the box a word with this count would be placed in, with the font and size scaling
+// of the cloud. It can be called before Draw and does not change the layout.
+func (w *Wordcloud) MeasureWord(word string, count int) (width float64, height float64) {
+	wc := wordCount{word: word, count: count, tier: -1}
+	for _, c := range w.sortedWordList {
+		if c.word == word {
+			wc.tier = c.tier
+			break
+		}
+	}
+	minSize := float64(w.opts.FontMinSize)
+	wc.size = w.snapSize(math.Max(wordSize(&w.opts, count, w.maxCount, wc.tier)*w.lengthFactor(word), minSize))
+	if w.sizeFactor != 1 {
+		wc.size = w.snapDown(math.Max(wc.size*w.sizeFactor, minSize))
+	}
+
+	width, height = w.measureWord(gg.NewContext(1, 1), wc, w.text(wc))
+	padding := w.padding(word)
+	return width + 5 + 2*padding, height + 5 + 2*padding
+}
+
+// boxesImage draws the boxes of the collision grid: masks filled in gray and the outline of word boxes in black
+func (w *Wordcloud) boxesImage() image.Image {
+	dc := gg.NewContext(int(w.width), int(w.height))
+	dc.SetColor(w.opts.BackgroundColor)
+	dc.Clear()
+
+	dc.SetRGB(0.8, 0.8, 0.8)
+	for _, b := range w.grid.Boxes(true) {
+		dc.DrawRectangle(b.x(), b.y(), b.w(), b.h())
+	}
+	dc.Fill()
+
+	dc.SetRGB(0, 0, 0)
+	dc.SetLineWidth(1)
+	for _, b := range w.grid.Boxes(false) {
+		dc.DrawRectangle(b.x(), b.y(), b.w(), b.h())
+	}
+	dc.Stroke()
+	return dc.Image()
+}
@@ -0,0 +1,18 @@
+package wordclouds
+
+// repeatWords returns the instances of the words drawn with RepeatMode: each word as many times as its count, up to
+// the cap, at the minimum font size. The instances are interleaved so that every word gets its first instance placed
+// before the others get their second.
+func repeatWords(words []wordCount, opts *Options) []wordCount {
+	res := make([]wordCount, 0, len(words))
+	for k := 0; k < opts.RepeatMode; k++ {
+		for _, wc := range words {
+			if wc.count <= k {
+				continue
+			}
+			wc.size = float64(opts.FontMinSize)
+			res = append(res, wc)
+		}
+	}
+	return res
+}
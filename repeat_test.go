@@ -0,0 +1,23 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_RepeatMode(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"five": 5, "two": 2, "many": 100},
+		FontMinSize(15),
+		RepeatMode(8),
+	)
+	w.Draw()
+
+	instances := map[string]int{}
+	for _, pw := range w.placed {
+		instances[pw.word]++
+		assert.Equal(t, 15.0, pw.size)
+	}
+	assert.Equal(t, map[string]int{"five": 5, "two": 2, "many": 8}, instances)
+	assert.Empty(t, w.VerifyNoOverlap())
+}
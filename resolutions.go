@@ -0,0 +1,49 @@
+package wordclouds
+
+import (
+	"image"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// DrawSizes lays the cloud out once, drawing it first if needed, and renders the words at each of the given sizes.
+// Positions are scaled to the size, keeping their place relative to the canvas, and fonts are rendered at the scaled
+// size rather than resampled. On a size with another aspect ratio, words are scaled by the smaller of the horizontal
+// and vertical factors so that they do not overlap. Relations, the watermark and the title are not drawn.
+func (w *Wordcloud) DrawSizes(sizes []image.Point) map[image.Point]image.Image {
+	if !w.drawn {
+		w.Draw()
+	}
+	res := make(map[image.Point]image.Image, len(sizes))
+	for _, size := range sizes {
+		if _, ok := res[size]; ok {
+			continue
+		}
+		res[size] = w.drawSize(size)
+	}
+	return res
+}
+
+// drawSize renders the placed words on a canvas of the given size
+func (w *Wordcloud) drawSize(size image.Point) image.Image {
+	dc := gg.NewContext(size.X, size.Y)
+	dc.SetColor(w.opts.BackgroundColor)
+	dc.Clear()
+	if len(w.opts.BackgroundGradient) > 0 {
+		dc.DrawImage(gradientImage(size.X, size.Y, w.opts.BackgroundGradient, w.opts.GradientKind), 0, 0)
+	}
+
+	sx, sy := float64(size.X)/w.width, float64(size.Y)/w.height
+	scale := math.Min(sx, sy)
+	for _, pw := range w.placed {
+		pw.x *= sx
+		pw.y *= sy
+		pw.size *= scale
+		pw.width *= scale
+		pw.height *= scale
+		pw.bar *= scale
+		w.renderWord(dc, &pw, 0, 0)
+	}
+	return dc.Image()
+}
@@ -0,0 +1,48 @@
+package wordclouds
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_DrawSizes(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+	)
+	sizes := []image.Point{{200, 200}, {400, 400}, {800, 800}}
+	images := w.DrawSizes(sizes)
+	assert.Len(t, images, 3)
+	assert.Equal(t, sizes[2], images[sizes[2]].Bounds().Size())
+
+	// Center and extent of the drawn pixels, relative to the canvas
+	layout := func(img image.Image) [4]float64 {
+		size := img.Bounds().Size()
+		left, top, right, bottom := size.X, size.Y, 0, 0
+		var cx, cy, n float64
+		for x := 0; x < size.X; x++ {
+			for y := 0; y < size.Y; y++ {
+				if r, _, _, _ := img.At(x, y).RGBA(); r < 0x8000 {
+					left, top, right, bottom = min(left, x), min(top, y), max(right, x), max(bottom, y)
+					cx, cy, n = cx+float64(x), cy+float64(y), n+1
+				}
+			}
+		}
+		return [4]float64{
+			cx / n / float64(size.X),
+			cy / n / float64(size.Y),
+			float64(right-left) / float64(size.X),
+			float64(bottom-top) / float64(size.Y),
+		}
+	}
+	reference := layout(w.image())
+	for _, size := range sizes {
+		relative := layout(images[size])
+		for i := range reference {
+			// Small fonts do not rasterize exactly the same at other sizes
+			assert.InDelta(t, reference[i], relative[i], 0.015, size)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+const (
+	// Steps in pixels between the positions tested along the river and between its lanes
+	riverStep     = 3
+	riverLaneStep = 5
+)
+
+// riverY returns the height of the curve of the river at x
+func (w *Wordcloud) riverY(x float64) float64 {
+	return w.height/2 + w.opts.RiverAmplitude*math.Sin(2*math.Pi*x/w.opts.RiverWavelength)
+}
+
+// riverAngle returns the rotation in degrees of a word following the tangent of the river at x
+func (w *Wordcloud) riverAngle(x float64) float64 {
+	slope := w.opts.RiverAmplitude * 2 * math.Pi / w.opts.RiverWavelength * math.Cos(2*math.Pi*x/w.opts.RiverWavelength)
+	// Image coordinates: a curve going down is a clockwise rotation
+	return -gg.Degrees(math.Atan(slope))
+}
+
+// nextRiver returns the leftmost free position along the river, on the lane closest to its curve. Lanes are
+// parallel to the curve, up to a quarter of the canvas height above and below it.
+func (w *Wordcloud) nextRiver(width float64, height float64) (x float64, y float64, angle float64, space bool) {
+	defer func() {
+		if space {
+			w.attempt.radius = math.Hypot(x-w.width/2, y-w.height/2)
+		}
+	}()
+	for lane := 0.0; lane <= w.height/4; lane += riverLaneStep {
+		for _, shift := range []float64{lane, -lane} {
+			for x = w.opts.EdgeMargin; x <= w.width-w.opts.EdgeMargin; x += riverStep {
+				w.attempt.tries++
+				y = w.riverY(x) + shift
+				angle = w.riverAngle(x)
+				if w.testRotated(x, y, width, height, angle) {
+					return x, y, angle, true
+				}
+			}
+			if lane == 0 {
+				break
+			}
+		}
+	}
+	return 0, 0, 0, false
+}
@@ -0,0 +1,32 @@
+package wordclouds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_WordRiver(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(40),
+		FontMinSize(10),
+		WordRiver(60, 300),
+	)
+	w.Draw()
+
+	assert.Greater(t, len(w.placed), 20)
+	assert.InDelta(t, w.riverY(w.placed[0].x), w.placed[0].y, 1e-9)
+	onCurve := 0
+	for _, pw := range w.placed {
+		sine := 200 + 60*math.Sin(2*math.Pi*pw.x/300)
+		assert.LessOrEqual(t, math.Abs(pw.y-sine), 100.0, pw.word)
+		if math.Abs(pw.y-sine) < 1e-9 {
+			onCurve++
+		}
+		slope := 60 * 2 * math.Pi / 300 * math.Cos(2*math.Pi*pw.x/300)
+		assert.InDelta(t, -math.Atan(slope)*180/math.Pi, pw.angle, 1e-9, pw.word)
+	}
+	assert.Greater(t, onCurve, 5)
+	assert.Empty(t, w.VerifyNoOverlap())
+}
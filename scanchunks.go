@@ -0,0 +1,62 @@
+package wordclouds
+
+import "math"
+
+// scanChunks returns the boxes covering the non-background pixels of the canvas in the box, like
+// getPreciseBoundingBoxes. The box is scanned row by row, in chunks of PreciseScanChunk rows: consecutive pixels of a
+// row are coalesced into one box, and the boxes of consecutive rows of a chunk with the same extent are merged.
+func (w *Wordcloud) scanChunks(b *Box, step int) []*Box {
+	res := make([]*Box, 0)
+	chunk := w.opts.PreciseScanChunk
+	left, right := int(math.Floor(b.Left)), int(b.Right)
+	// Boxes of the previous row of the chunk, which the runs of the current row can extend
+	var open, current []*Box
+	for j := int(b.Bottom); j < int(b.Top); j += step {
+		if (j-int(b.Bottom))/step%chunk == 0 {
+			open = open[:0]
+		}
+		current = current[:0]
+		start := -1
+		i := left
+		for ; i < right; i += step {
+			hit := w.isDrawn(i, j)
+			if hit && start < 0 {
+				start = i
+			}
+			if hit || start < 0 {
+				continue
+			}
+			current = append(current, w.extendRun(&res, open, float64(start)-5, float64(i)+5, j, step))
+			start = -1
+		}
+		if start >= 0 {
+			current = append(current, w.extendRun(&res, open, float64(start)-5, float64(i)+5, j, step))
+		}
+		open, current = current, open
+	}
+	return res
+}
+
+// extendRun returns the box of the run of pixels of row j from left to right, the box of the previous row above it
+// if it has the same extent, or a new box appended to res otherwise
+func (w *Wordcloud) extendRun(res *[]*Box, open []*Box, left float64, right float64, j int, step int) *Box {
+	for _, o := range open {
+		if o.Left == left && o.Right == right {
+			o.Top = float64(j+step) + 5
+			return o
+		}
+	}
+	box := &Box{float64(j+step) + 5, left, right, float64(j) - 5}
+	*res = append(*res, box)
+	return box
+}
+
+// isDrawn returns whether the pixel of the canvas differs from the background
+func (w *Wordcloud) isDrawn(x int, y int) bool {
+	background := w.opts.BackgroundColor
+	if w.background != nil {
+		// The background is not of a single color
+		background = w.background.At(x, y)
+	}
+	return w.dc.Image().At(x, y) != background
+}
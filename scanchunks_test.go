@@ -0,0 +1,69 @@
+package wordclouds
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_PreciseScanChunk(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"disc": 10},
+		FontMaxSize(200),
+		BackgroundColor(color.RGBA{0xff, 0xff, 0xff, 0xff}),
+		PreciseScanStep(2),
+		Icons(map[string]image.Image{"disc": discIcon(100)}),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, 1)
+	bounds := w.placed[0].bounds
+
+	pixels := w.getPreciseBoundingBoxes(bounds)
+	w.opts.PreciseScanChunk = 8
+	chunks := w.getPreciseBoundingBoxes(bounds)
+	assert.True(t, len(chunks) < len(pixels)/10)
+
+	// The coalesced boxes cover the same pixels
+	for _, p := range pixels {
+		covered := false
+		for _, c := range chunks {
+			covered = covered || (c.Left <= p.Left && c.Right >= p.Right && c.Bottom <= p.Bottom && c.Top >= p.Top)
+		}
+		assert.True(t, covered)
+	}
+	for _, c := range chunks {
+		x, y := int(c.Left+5), int(c.Bottom+5)
+		assert.True(t, w.isDrawn(x, y))
+		assert.False(t, c.h() > float64(8*2+10))
+	}
+}
+
+func BenchmarkWordcloud_PreciseScanChunk(b *testing.B) {
+	words := map[string]int{}
+	icons := map[string]image.Image{}
+	for i := 0; i < 20; i++ {
+		word := fmt.Sprintf("disc%d", i)
+		words[word] = 1 + i
+		icons[word] = discIcon(100)
+	}
+	for _, chunk := range []int{0, 16} {
+		b.Run(fmt.Sprintf("chunk=%d", chunk), func(b *testing.B) {
+			entries := 0
+			for i := 0; i < b.N; i++ {
+				w := newTestCloud(b, words,
+					FontMaxSize(300),
+					Width(1024),
+					Height(1024),
+					PreciseScanStep(2),
+					PreciseScanChunk(chunk),
+					Icons(icons),
+				)
+				w.Draw()
+				entries += len(w.grid.Boxes(false))
+			}
+			b.ReportMetric(float64(entries)/float64(b.N), "boxes/op")
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/fogleman/gg"
+)
+
+// ColorLayer is the coverage of the words drawn in one color, e.g. to print a color separation
+type ColorLayer struct {
+	Color color.Color
+	// Opaque where the color is printed, transparent elsewhere
+	Mask *image.Alpha
+}
+
+// ColorLayers renders one mask per color of the palette, holding only the words drawn in that color. Words are assigned
+// to the closest color of the palette, so that faded words go with their ink, and translucent words are partially
+// covering. Without a palette, each color the words are drawn in gets a layer. Drawing every layer color through its
+// mask over the background gives back the words of the cloud. Call it after Draw.
+func (w *Wordcloud) ColorLayers(palette color.Palette) []ColorLayer {
+	if palette == nil {
+		seen := make(map[color.NRGBA]bool)
+		for _, pw := range w.placed {
+			c := opaque(pw.color)
+			if !seen[c] {
+				seen[c] = true
+				palette = append(palette, c)
+			}
+		}
+	}
+
+	if len(palette) == 0 {
+		return nil
+	}
+	contexts := make([]*gg.Context, len(palette))
+	for _, pw := range w.placed {
+		i := palette.Index(opaque(pw.color))
+		if contexts[i] == nil {
+			contexts[i] = gg.NewContext(int(w.width), int(w.height))
+		}
+		// The coverage of the word is the alpha of the word drawn in white, as translucent as the word
+		_, _, _, a := pw.color.RGBA()
+		pw.color = color.NRGBA{0xff, 0xff, 0xff, uint8(a >> 8)}
+		w.renderWord(contexts[i], &pw, 0, 0)
+	}
+
+	layers := make([]ColorLayer, 0, len(palette))
+	for i, c := range palette {
+		mask := image.NewAlpha(image.Rect(0, 0, int(w.width), int(w.height)))
+		if contexts[i] != nil {
+			draw.Draw(mask, mask.Bounds(), contexts[i].Image(), image.Point{}, draw.Src)
+		}
+		layers = append(layers, ColorLayer{c, mask})
+	}
+	return layers
+}
+
+// opaque returns the color without its transparency
+func opaque(c color.Color) color.NRGBA {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	n.A = 0xff
+	return n
+}
@@ -0,0 +1,67 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_ColorLayers(t *testing.T) {
+	red := color.RGBA{R: 0xcc, G: 0x22, B: 0x22, A: 0xff}
+	blue := color.RGBA{R: 0x22, G: 0x22, B: 0xcc, A: 0xff}
+	w := NewWordcloud(loadTestWords(t),
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(60),
+		FontMinSize(10),
+		Colors([]color.Color{red, blue}),
+		Width(300),
+		Height(300),
+	)
+	img := w.Draw()
+
+	layers := w.ColorLayers(nil)
+	assert.Len(t, layers, 2)
+
+	// Printing every layer over the background gives back the cloud
+	union := image.NewRGBA(img.Bounds())
+	draw.Draw(union, union.Bounds(), image.NewUniform(w.opts.BackgroundColor), image.Point{}, draw.Src)
+	for _, l := range layers {
+		draw.DrawMask(union, union.Bounds(), image.NewUniform(l.Color), image.Point{}, l.Mask, image.Point{}, draw.Over)
+	}
+	differing := 0
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 300; y++ {
+			r1, g1, b1, _ := img.At(x, y).RGBA()
+			r2, g2, b2, _ := union.At(x, y).RGBA()
+			if diff(r1, r2) > 0x300 || diff(g1, g2) > 0x300 || diff(b1, b2) > 0x300 {
+				differing++
+			}
+		}
+	}
+	assert.Equal(t, 0, differing)
+
+	// Words go with the closest color of the palette
+	layers = w.ColorLayers(color.Palette{color.Black, color.RGBA{R: 0xff, A: 0xff}, color.White})
+	assert.Len(t, layers, 3)
+	covered := func(mask *image.Alpha) bool {
+		for _, a := range mask.Pix {
+			if a != 0 {
+				return true
+			}
+		}
+		return false
+	}
+	assert.True(t, covered(layers[0].Mask))
+	assert.True(t, covered(layers[1].Mask))
+	assert.False(t, covered(layers[2].Mask))
+}
+
+func diff(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,33 @@
+package wordclouds
+
+import "math"
+
+// snapSize returns the font size of FontSizeSteps closest to size, within FontMinSize and FontMaxSize. The size is
+// returned as is without steps.
+func (w *Wordcloud) snapSize(size float64) float64 {
+	if len(w.opts.FontSizeSteps) == 0 {
+		return size
+	}
+	best := w.opts.FontSizeSteps[0]
+	for _, step := range w.opts.FontSizeSteps[1:] {
+		if math.Abs(step-size) < math.Abs(best-size) {
+			best = step
+		}
+	}
+	return math.Max(math.Min(best, float64(w.opts.FontMaxSize)), float64(w.opts.FontMinSize))
+}
+
+// snapDown returns the largest font size of FontSizeSteps up to size, for words shrunk to fit, or FontMinSize if there
+// is none. The size is returned as is without steps.
+func (w *Wordcloud) snapDown(size float64) float64 {
+	if len(w.opts.FontSizeSteps) == 0 {
+		return size
+	}
+	best := float64(w.opts.FontMinSize)
+	for _, step := range w.opts.FontSizeSteps {
+		if step <= size && step > best {
+			best = step
+		}
+	}
+	return math.Min(best, float64(w.opts.FontMaxSize))
+}
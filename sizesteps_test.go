@@ -0,0 +1,76 @@
+package wordclouds
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_FontSizeSteps(t *testing.T) {
+	steps := []float64{12, 18, 28, 44, 200}
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		Width(600),
+		Height(600),
+		RetryPasses(1),
+		FontSizeSteps(steps),
+	)
+	w.Draw()
+
+	sizes := map[float64]bool{}
+	for _, pw := range w.placed {
+		sizes[pw.size] = true
+	}
+	assert.Equal(t, map[float64]bool{12: true, 18: true, 28: true, 44: true}, sizes)
+
+	assert.Equal(t, 28.0, w.snapSize(24))
+	assert.Equal(t, 12.0, w.snapSize(5))
+	// 200 is above FontMaxSize
+	assert.Equal(t, 60.0, w.snapSize(150))
+}
+
+func TestWordcloud_FontSizeStepsShrinkOversize(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"oversized": 10, "small": 1},
+		FontMaxSize(200),
+		FontMinSize(10),
+		Width(300),
+		Height(300),
+		RetryPasses(2),
+		FontSizeSteps([]float64{20, 40, 80, 200}),
+		Oversize(ShrinkOversize),
+	)
+	w.Draw()
+
+	// The word fits at about 60, it is not snapped back to 80
+	assert.Equal(t, []string{"oversized", "small"}, w.Result().Placed)
+	assert.Equal(t, 40.0, w.placed[0].size)
+	assert.Equal(t, 20.0, w.placed[1].size)
+	assert.Equal(t, 20.0, w.snapDown(39))
+	assert.Equal(t, 10.0, w.snapDown(15))
+}
+
+func TestWordcloud_FontSizeStepsSphere(t *testing.T) {
+	words := map[string]int{}
+	for i := 0; i < 60; i++ {
+		words[fmt.Sprintf("word%d", i)] = 5
+	}
+	steps := []float64{10, 14, 20}
+	w := newTestCloud(t, words,
+		FontMaxSize(20),
+		FontMinSize(10),
+		FontSizeSteps(steps),
+		SphereProjection(0.8),
+	)
+	w.Draw()
+	assert.Empty(t, w.VerifyNoOverlap())
+
+	// The words shrunk on the sphere are snapped down to the steps
+	sizes := map[float64]bool{}
+	for _, pw := range w.placed {
+		assert.Contains(t, steps, pw.size)
+		sizes[pw.size] = true
+	}
+	assert.Greater(t, len(sizes), 1)
+}
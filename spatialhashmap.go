@@ -7,6 +7,8 @@ import (
 type uniqueBox struct {
 	uuid.UUID
 	b *Box
+	// Masks block placement but are not words
+	mask bool
 }
 
 type spatialHashMap struct {
@@ -17,8 +19,14 @@ type spatialHashMap struct {
 }
 
 func (s *spatialHashMap) TestCollision(b *Box, test func(a *Box, b *Box) bool) (bool, int) {
+	return s.TestCollisionNear(b, 0, test)
+}
+
+// TestCollisionNear is TestCollision also testing the boxes in the cells less than distance pixels away from b
+func (s *spatialHashMap) TestCollisionNear(b *Box, distance float64, test func(a *Box, b *Box) bool) (bool, int) {
 	overlaps := 0
-	top, left, right, bottom := s.toGridCoords(b)
+	top, left, right, bottom := s.toGridCoords(&Box{b.Top + distance, b.Left - distance, b.Right + distance,
+		b.Bottom - distance})
 	for i := left; i <= right; i++ {
 		for j := bottom; j <= top; j++ {
 			for _, ub := range s.mat[i][j] {
@@ -33,15 +41,58 @@ func (s *spatialHashMap) TestCollision(b *Box, test func(a *Box, b *Box) bool) (
 }
 
 func (s *spatialHashMap) Add(b *Box) {
+	s.add(b, false)
+}
+
+// AddMask adds a box blocking placement that is not part of a word
+func (s *spatialHashMap) AddMask(b *Box) {
+	s.add(b, true)
+}
+
+func (s *spatialHashMap) add(b *Box, mask bool) {
 	id := uuid.New()
 	top, left, right, bottom := s.toGridCoords(b)
 	for i := left; i <= right; i++ {
 		for j := bottom; j <= top; j++ {
-			s.mat[i][j] = append(s.mat[i][j], &uniqueBox{id, b})
+			s.mat[i][j] = append(s.mat[i][j], &uniqueBox{id, b, mask})
 		}
 	}
 }
 
+// Occupancy counts the word boxes in the cells spanned by the box
+func (s *spatialHashMap) Occupancy(b *Box) int {
+	occupancy := 0
+	top, left, right, bottom := s.toGridCoords(b)
+	for i := left; i <= right; i++ {
+		for j := bottom; j <= top; j++ {
+			for _, ub := range s.mat[i][j] {
+				if !ub.mask {
+					occupancy++
+				}
+			}
+		}
+	}
+	return occupancy
+}
+
+// Boxes returns the boxes added with AddMask if mask is set, or with Add otherwise, each once
+func (s *spatialHashMap) Boxes(mask bool) []*Box {
+	seen := make(map[uuid.UUID]bool)
+	res := make([]*Box, 0)
+	for i := range s.mat {
+		for j := range s.mat[i] {
+			for _, ub := range s.mat[i][j] {
+				if ub.mask != mask || seen[ub.UUID] {
+					continue
+				}
+				seen[ub.UUID] = true
+				res = append(res, ub.b)
+			}
+		}
+	}
+	return res
+}
+
 func newSpatialHashMap(windowWidth float64, windowHeight float64, gridSize int) *spatialHashMap {
 	rw := windowWidth / float64(gridSize)
 	rh := windowHeight / float64(gridSize)
@@ -69,6 +120,14 @@ func min(a int, b int) int {
 	return b
 }
 
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func (s *spatialHashMap) toGridCoords(b *Box) (int, int, int, int) {
-	return min(int(b.Top/s.rh), s.gridSize-1), int(b.Left / s.rw), min(int(b.Right/s.rw), s.gridSize-1), int(b.Bottom / s.rh)
+	return min(int(b.Top/s.rh), s.gridSize-1), max(int(b.Left/s.rw), 0), min(int(b.Right/s.rw), s.gridSize-1),
+		max(int(b.Bottom/s.rh), 0)
 }
@@ -0,0 +1,88 @@
+package wordclouds
+
+import (
+	"image"
+	"math"
+)
+
+// sphereTransform returns where a word centered on x, y goes when the canvas is projected onto a sphere, and how much
+// the word is scaled. The distance to the center of the canvas is mapped to an angle on the sphere, up to
+// strength*90° at the corners. The words are shrunk by the cosine of the angle at the distance of their farthest
+// corner, extent away from their center, so that they shrink at least as much as the sphere under them.
+func (w *Wordcloud) sphereTransform(x float64, y float64, extent float64) (px float64, py float64, scale float64) {
+	cx, cy := w.width/2, w.height/2
+	r := math.Hypot(x-cx, y-cy)
+	// Angle on the sphere per pixel from the center
+	k := w.opts.SphereStrength * math.Pi / 2 / math.Hypot(cx, cy)
+	scale = math.Cos(math.Min(k*(r+extent), math.Pi/2))
+	if r == 0 {
+		return x, y, scale
+	}
+	// The distance to the center becomes sin(k*r)/k
+	ratio := math.Sin(k*r) / (k * r)
+	return cx + (x-cx)*ratio, cy + (y-cy)*ratio, scale
+}
+
+// projectWords moves and shrinks the placed words as if the canvas were projected onto a sphere, and draws them
+// again over base, the canvas before any word. The words near the edges get smaller and closer together, the center is
+// left as is. Words moved onto a mask, the title or the watermark, or out of the region, are dropped and returned.
+func (w *Wordcloud) projectWords(base image.Image) (dropped []wordCount) {
+	if w.opts.SphereStrength <= 0 || len(w.placed) == 0 {
+		return nil
+	}
+
+	// Boxes may be shared by the fields of a word
+	transformed := make(map[*Box]bool)
+	w.resetGrid()
+	kept := make([]placedWord, 0, len(w.placed))
+	for _, pw := range w.placed {
+		b := pw.bounds
+		extent := math.Hypot(math.Max(b.Right-pw.x, pw.x-b.Left), math.Max(b.Top-pw.y, pw.y-b.Bottom))
+		x, y, scale := w.sphereTransform(pw.x, pw.y, extent)
+		// The size is snapped down to FontSizeSteps, the word never gets larger than its place on the sphere
+		size := w.snapDown(pw.size * scale)
+		scale = size / pw.size
+		transform := func(b *Box) {
+			if b == nil || transformed[b] {
+				return
+			}
+			transformed[b] = true
+			b.Top, b.Bottom = y+(b.Top-pw.y)*scale, y+(b.Bottom-pw.y)*scale
+			b.Left, b.Right = x+(b.Left-pw.x)*scale, x+(b.Right-pw.x)*scale
+		}
+		area := pw.box.area()
+		transform(pw.box)
+		transform(pw.bounds)
+		blocked := len(w.opts.Region) >= 3 && !inPolygon(pw.bounds, w.opts.Region)
+		for _, b := range pw.boxes {
+			transform(b)
+			// The grid only holds the masks and bands, the projected words do not overlap
+			if colliding, _ := w.grid.TestCollision(b, (*Box).overlaps); colliding {
+				blocked = true
+			}
+		}
+		w.wordsArea -= area
+		if blocked {
+			dropped = append(dropped, pw.wordCount)
+			continue
+		}
+		w.wordsArea += pw.box.area()
+		pw.x, pw.y = x, y
+		pw.size = size
+		pw.width *= scale
+		pw.height *= scale
+		pw.bar *= scale
+		kept = append(kept, pw)
+	}
+
+	w.placed = kept
+	if !w.opts.NoCollision {
+		for _, pw := range kept {
+			for _, b := range pw.boxes {
+				w.grid.Add(b)
+			}
+		}
+	}
+	w.redrawWords(base)
+	return dropped
+}
@@ -0,0 +1,88 @@
+package wordclouds
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/fogleman/gg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_SphereProjection(t *testing.T) {
+	words := map[string]int{}
+	for i := 0; i < 60; i++ {
+		words[fmt.Sprintf("word%d", i)] = 5
+	}
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(20),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+	}
+	flat := NewWordcloud(words, options...)
+	flat.Draw()
+	w := NewWordcloud(words, append(options, SphereProjection(0.8))...)
+	w.Draw()
+	assert.Equal(t, len(flat.placed), len(w.placed))
+	assert.Empty(t, w.VerifyNoOverlap())
+
+	// Words are shrunk and pulled in with their distance to the center
+	for i, pw := range w.placed {
+		before := flat.placed[i]
+		r := math.Hypot(before.x-200, before.y-200)
+		scale := pw.size / before.size
+		assert.True(t, scale <= 1)
+		assert.True(t, scale <= math.Cos(0.8*math.Pi/2*r/math.Hypot(200, 200)))
+		assert.True(t, math.Hypot(pw.x-200, pw.y-200) <= r)
+		assert.InDelta(t, scale*before.box.w(), pw.box.w(), 1e-9)
+	}
+	center, edge := w.placed[0], w.placed[len(w.placed)-1]
+	assert.Less(t, edge.size, center.size*0.9)
+}
+
+func TestWordcloud_SphereProjectionCanvas(t *testing.T) {
+	dc := gg.NewContext(400, 400)
+	w := newTestCloud(t, map[string]int{"globe": 10, "words": 6},
+		FontMaxSize(40),
+		MaskBoxes([]*Box{{400, 360, 400, 360}}),
+		Debug(),
+		WithContext(dc),
+		SphereProjection(0.8),
+	)
+	red := color.RGBA{R: 0xff, A: 0xff}
+	dc.SetColor(red)
+	dc.DrawRectangle(0, 380, 20, 20)
+	dc.Fill()
+	img := w.Draw()
+	assert.Len(t, w.placed, 2)
+
+	// What was on the canvas before Draw is kept: the outline of the mask and the drawing on the context
+	assert.NotEqual(t, color.RGBAModel.Convert(color.White), color.RGBAModel.Convert(img.At(360, 380)))
+	assert.Equal(t, color.RGBAModel.Convert(red), color.RGBAModel.Convert(img.At(10, 390)))
+}
+
+func TestWordcloud_SphereProjectionMask(t *testing.T) {
+	words := map[string]int{}
+	for i := 0; i < 60; i++ {
+		words[fmt.Sprintf("word%d", i)] = 5
+	}
+	mask := &Box{260, 140, 260, 140}
+	w := newTestCloud(t, words,
+		FontMaxSize(20),
+		MaskBoxes([]*Box{mask}),
+		SphereProjection(1),
+	)
+	w.Draw()
+
+	// Words pulled onto the mask are skipped
+	assert.NotEmpty(t, w.Result().Skipped)
+	assert.Len(t, append(w.Result().Placed, w.Result().Skipped...), len(words))
+	for _, pw := range w.placed {
+		for _, b := range pw.boxes {
+			assert.False(t, b.overlaps(mask), pw.word)
+		}
+	}
+}
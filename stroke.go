@@ -0,0 +1,52 @@
+package wordclouds
+
+import (
+	"github.com/fogleman/gg"
+	"github.com/golang/freetype/truetype"
+)
+
+// strokeWidth returns the width of the stroke around the glyphs of a word with this font size, 0 without strokes or
+// with a FontProvider, whose glyph outlines are not known
+func (w *Wordcloud) strokeWidth(size float64) float64 {
+	if w.opts.StrokeWidth == nil || w.opts.FontProvider != nil {
+		return 0
+	}
+	return w.opts.StrokeWidth(w.pixelSize(size))
+}
+
+// strokeText strokes the outlines of the glyphs of the placed word, the text being centered on x, y. The word
+// rotation must already be applied to dc.
+func (w *Wordcloud) strokeText(dc *gg.Context, pw *placedWord, x float64, y float64) {
+	width := w.strokeWidth(pw.size)
+	if width <= 0 {
+		return
+	}
+	w.eachGlyph(pw, x, y, func(buf *truetype.GlyphBuf, gx float64, gy float64) {
+		start := 0
+		for _, end := range buf.Ends {
+			first := true
+			walkContour(buf.Points[start:end], func(a point, b point) {
+				if first {
+					dc.MoveTo(gx+a.x, gy-a.y)
+					first = false
+				}
+				dc.LineTo(gx+b.x, gy-b.y)
+			}, func(a point, b point, c point) {
+				if first {
+					dc.MoveTo(gx+a.x, gy-a.y)
+					first = false
+				}
+				dc.QuadraticTo(gx+b.x, gy-b.y, gx+c.x, gy-c.y)
+			})
+			dc.ClosePath()
+			start = end
+		}
+	})
+	if w.opts.StrokeColor != nil {
+		dc.SetColor(w.opts.StrokeColor)
+	}
+	dc.SetLineWidth(width)
+	dc.SetLineJoinRound()
+	dc.Stroke()
+	dc.SetColor(pw.color)
+}
@@ -0,0 +1,45 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_WordStroke(t *testing.T) {
+	words := map[string]int{"big": 10, "small": 2}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(100),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(300),
+	}
+	plain := NewWordcloud(words, options...)
+	w := NewWordcloud(words, append(options, WordStroke(func(size float64) float64 { return size / 10 }, blue))...)
+
+	// The stroke grows the boxes with the font size
+	for word, count := range words {
+		size := wordSize(&w.opts, count, w.maxCount, -1)
+		plainWidth, plainHeight := plain.MeasureWord(word, count)
+		width, height := w.MeasureWord(word, count)
+		assert.InDelta(t, size/10, width-plainWidth, 1e-9)
+		assert.InDelta(t, size/10, height-plainHeight, 1e-9)
+	}
+
+	img := w.Draw()
+	assert.Len(t, w.placed, 2)
+	assert.Empty(t, w.VerifyNoOverlap())
+	stroked := 0
+	bounds := img.Bounds()
+	for x := bounds.Min.X; x < bounds.Max.X; x++ {
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			if color.RGBAModel.Convert(img.At(x, y)) == blue {
+				stroked++
+			}
+		}
+	}
+	assert.Greater(t, stroked, 100)
+}
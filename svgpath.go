@@ -0,0 +1,275 @@
+package wordclouds
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/fogleman/gg"
+)
+
+// Number of arguments of each SVG path command
+var svgPathArgs = map[byte]int{
+	'M': 2, 'L': 2, 'H': 1, 'V': 1, 'C': 6, 'S': 4, 'Q': 4, 'T': 2, 'A': 7, 'Z': 0,
+}
+
+// svgMask rasterizes the SVG path data, in a width x height coordinate system, and returns the boxes covering the
+// outside of the path, scaled to the canvas.
+func svgMask(d string, width int, height int, canvasWidth int, canvasHeight int) ([]*Box, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid path coordinate system %dx%d", width, height)
+	}
+	// Rasterize at the canvas resolution for a mask as precise as the path allows
+	scale := math.Min(float64(canvasWidth)/float64(width), float64(canvasHeight)/float64(height))
+	dc := gg.NewContext(int(math.Round(float64(width)*scale)), int(math.Round(float64(height)*scale)))
+	dc.Scale(scale, scale)
+	err := drawSVGPath(dc, d)
+	if err != nil {
+		return nil, err
+	}
+	dc.SetRGB(0, 0, 0)
+	dc.Fill()
+	return maskBoxes(dc.Image(), canvasWidth, canvasHeight, color.RGBA{}), nil
+}
+
+type svgPathParser struct {
+	d   string
+	pos int
+}
+
+func (p *svgPathParser) skipSeparators() {
+	for p.pos < len(p.d) {
+		switch p.d[p.pos] {
+		case ' ', ',', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *svgPathParser) done() bool {
+	p.skipSeparators()
+	return p.pos >= len(p.d)
+}
+
+func (p *svgPathParser) hasNumber() bool {
+	p.skipSeparators()
+	if p.pos >= len(p.d) {
+		return false
+	}
+	c := p.d[p.pos]
+	return c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9')
+}
+
+func (p *svgPathParser) command() (byte, error) {
+	p.skipSeparators()
+	c := p.d[p.pos]
+	if _, ok := svgPathArgs[c&^0x20]; !ok {
+		return 0, fmt.Errorf("invalid path command %q at %d", c, p.pos)
+	}
+	p.pos++
+	return c, nil
+}
+
+func (p *svgPathParser) number() (float64, error) {
+	p.skipSeparators()
+	start := p.pos
+	digits := func() {
+		for p.pos < len(p.d) && p.d[p.pos] >= '0' && p.d[p.pos] <= '9' {
+			p.pos++
+		}
+	}
+	if p.pos < len(p.d) && (p.d[p.pos] == '-' || p.d[p.pos] == '+') {
+		p.pos++
+	}
+	digits()
+	if p.pos < len(p.d) && p.d[p.pos] == '.' {
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.d) && (p.d[p.pos] == 'e' || p.d[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.d) && (p.d[p.pos] == '-' || p.d[p.pos] == '+') {
+			p.pos++
+		}
+		digits()
+	}
+	f, err := strconv.ParseFloat(p.d[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number at %d: %w", start, err)
+	}
+	return f, nil
+}
+
+// flag reads an arc flag, which may not be separated from the next argument
+func (p *svgPathParser) flag() (float64, error) {
+	p.skipSeparators()
+	if p.pos < len(p.d) && (p.d[p.pos] == '0' || p.d[p.pos] == '1') {
+		p.pos++
+		return float64(p.d[p.pos-1] - '0'), nil
+	}
+	return 0, fmt.Errorf("invalid arc flag at %d", p.pos)
+}
+
+// drawSVGPath adds the SVG path data to the current path of dc
+func drawSVGPath(dc *gg.Context, d string) error {
+	p := &svgPathParser{d: d}
+	// Current point, start of the current subpath and last control point
+	var cx, cy, sx, sy, lx, ly float64
+	var last byte
+
+	for !p.done() {
+		cmd, err := p.command()
+		if err != nil {
+			return err
+		}
+		upper := cmd &^ 0x20
+		if last == 0 && upper != 'M' {
+			return fmt.Errorf("path must start with a move command")
+		}
+
+		for {
+			args := make([]float64, svgPathArgs[upper])
+			for i := range args {
+				if upper == 'A' && (i == 3 || i == 4) {
+					args[i], err = p.flag()
+				} else {
+					args[i], err = p.number()
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			// Convert relative coordinates to absolute ones
+			if cmd != upper {
+				switch upper {
+				case 'H':
+					args[0] += cx
+				case 'V':
+					args[0] += cy
+				case 'A':
+					args[5] += cx
+					args[6] += cy
+				default:
+					for i := 0; i+1 < len(args); i += 2 {
+						args[i] += cx
+						args[i+1] += cy
+					}
+				}
+			}
+
+			// Reflection of the last control point for smooth curves
+			rx, ry := cx, cy
+			if (upper == 'S' && (last == 'C' || last == 'S')) || (upper == 'T' && (last == 'Q' || last == 'T')) {
+				rx, ry = 2*cx-lx, 2*cy-ly
+			}
+
+			switch upper {
+			case 'M':
+				dc.MoveTo(args[0], args[1])
+				cx, cy = args[0], args[1]
+				sx, sy = cx, cy
+			case 'L':
+				dc.LineTo(args[0], args[1])
+				cx, cy = args[0], args[1]
+			case 'H':
+				dc.LineTo(args[0], cy)
+				cx = args[0]
+			case 'V':
+				dc.LineTo(cx, args[0])
+				cy = args[0]
+			case 'C':
+				dc.CubicTo(args[0], args[1], args[2], args[3], args[4], args[5])
+				lx, ly = args[2], args[3]
+				cx, cy = args[4], args[5]
+			case 'S':
+				dc.CubicTo(rx, ry, args[0], args[1], args[2], args[3])
+				lx, ly = args[0], args[1]
+				cx, cy = args[2], args[3]
+			case 'Q':
+				dc.QuadraticTo(args[0], args[1], args[2], args[3])
+				lx, ly = args[0], args[1]
+				cx, cy = args[2], args[3]
+			case 'T':
+				dc.QuadraticTo(rx, ry, args[0], args[1])
+				lx, ly = rx, ry
+				cx, cy = args[0], args[1]
+			case 'A':
+				arcTo(dc, cx, cy, args[0], args[1], args[2], args[3] != 0, args[4] != 0, args[5], args[6])
+				cx, cy = args[5], args[6]
+			case 'Z':
+				dc.ClosePath()
+				cx, cy = sx, sy
+			}
+			last = upper
+
+			if upper == 'Z' || !p.hasNumber() {
+				break
+			}
+			// Coordinates following a move are implicit lines
+			if upper == 'M' {
+				upper = 'L'
+				cmd = cmd - 'M' + 'L'
+			}
+		}
+	}
+	return nil
+}
+
+// arcTo approximates an SVG elliptical arc from x1, y1 to x2, y2 with line segments, converting it to its center
+// parameterization as described in the SVG implementation notes.
+func arcTo(dc *gg.Context, x1 float64, y1 float64, rx float64, ry float64, rotation float64, large bool, sweep bool,
+	x2 float64, y2 float64) {
+	if rx == 0 || ry == 0 {
+		dc.LineTo(x2, y2)
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	phi := gg.Radians(rotation)
+	cos, sin := math.Cos(phi), math.Sin(phi)
+
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cos*dx + sin*dy
+	y1p := -sin*dx + cos*dy
+
+	// Scale up the radii if they are too small to join the points
+	lambda := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry)
+	if lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coef := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1p / ry
+	cyp := -coef * ry * x1p / rx
+	cx := cos*cxp - sin*cyp + (x1+x2)/2
+	cy := sin*cxp + cos*cyp + (y1+y2)/2
+
+	angle := func(ux float64, uy float64, vx float64, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	delta := angle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 32)))
+	for i := 1; i <= n; i++ {
+		t := theta + delta*float64(i)/float64(n)
+		dc.LineTo(
+			cx+rx*math.Cos(t)*cos-ry*math.Sin(t)*sin,
+			cy+rx*math.Cos(t)*sin+ry*math.Sin(t)*cos,
+		)
+	}
+}
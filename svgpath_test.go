@@ -0,0 +1,42 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func blocked(boxes []*Box, x float64, y float64) bool {
+	for _, b := range boxes {
+		if b.overlapsRaw(y, x, x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSvgMask(t *testing.T) {
+	// Triangle pointing down, in a canvas twice as large as the path coordinates
+	boxes, err := svgMask("M0 0 L100 0 L50 100 Z", 100, 100, 200, 200)
+	assert.NoError(t, err)
+	assert.False(t, blocked(boxes, 100, 50))
+	assert.True(t, blocked(boxes, 10, 150))
+	assert.True(t, blocked(boxes, 190, 150))
+
+	// Circle drawn with relative arcs
+	boxes, err = svgMask("M50 0a50 50 0 1 1 0 100a50 50 0 1 1 0-100z", 100, 100, 100, 100)
+	assert.NoError(t, err)
+	assert.False(t, blocked(boxes, 50, 50))
+	assert.False(t, blocked(boxes, 20, 50))
+	assert.True(t, blocked(boxes, 5, 5))
+	assert.True(t, blocked(boxes, 95, 95))
+
+	_, err = svgMask("L0 0", 100, 100, 100, 100)
+	assert.Error(t, err)
+	_, err = svgMask("M0 0 X", 100, 100, 100, 100)
+	assert.Error(t, err)
+	_, err = svgMask("M0 0 L100 0 L50 100 Z", 0, 100, 100, 100)
+	assert.Error(t, err)
+	_, err = svgMask("M0 0 L100 0 L50 100 Z", 100, -1, 100, 100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package wordclouds
+
+import "math"
+
+// Weight of the word with the highest TF-IDF in the result of CountTFIDF
+const tfidfScale = 1000
+
+// CountTFIDF counts the words of several documents like CountWords, weighted by their TF-IDF: a word found in few
+// documents weighs more than a word as frequent but found in all of them. The weights are scaled to integers, the
+// highest being 1000, so the result can be used as the word list of NewWordcloud. Words found in every document get
+// the lowest weight of 1.
+func CountTFIDF(documents []string, options ...CountOption) map[string]int {
+	counts := make([]map[string]int, 0, len(documents))
+	// Number of documents each word is found in
+	found := make(map[string]int)
+	for _, d := range documents {
+		c := CountWords(d, options...)
+		for word := range c {
+			found[word]++
+		}
+		counts = append(counts, c)
+	}
+
+	weights := make(map[string]float64, len(found))
+	highest := 0.0
+	n := float64(len(documents))
+	for _, c := range counts {
+		total := 0
+		for _, count := range c {
+			total += count
+		}
+		for word, count := range c {
+			idf := math.Log(n / float64(found[word]))
+			weights[word] += float64(count) / float64(total) * idf
+			highest = math.Max(highest, weights[word])
+		}
+	}
+
+	res := make(map[string]int, len(weights))
+	for word, weight := range weights {
+		if highest == 0 {
+			res[word] = 1
+			continue
+		}
+		res[word] = int(math.Max(1, math.Round(weight/highest*tfidfScale)))
+	}
+	return res
+}
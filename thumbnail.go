@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"image"
+	"math"
+
+	"github.com/fogleman/gg"
+)
+
+// Font size the word is measured at before being scaled to the canvas
+const thumbnailMeasureSize = 100
+
+// Thumbnail renders a single word, as large as the canvas allows and centered, without laying out the cloud.
+// The word defaults to the most frequent one when empty. It is a cheap preview for lists and feeds.
+func (w *Wordcloud) Thumbnail(word string) image.Image {
+	dc := gg.NewContext(int(w.width), int(w.height))
+	w.clearBackground(dc)
+	if len(w.sortedWordList) == 0 {
+		return dc.Image()
+	}
+
+	wc := w.sortedWordList[0]
+	for _, c := range w.sortedWordList {
+		if c.word == word {
+			wc = c
+			break
+		}
+	}
+
+	pw := placedWord{
+		wordCount: wc,
+		text:      w.text(wc),
+		x:         w.width / 2,
+		y:         w.height / 2,
+		color:     w.wordColor(wc),
+	}
+	dc.SetFontFace(w.face(pw.text, thumbnailMeasureSize))
+	width, height := w.measureString(dc, pw.text)
+	// Leave room for the descenders
+	height += 0.3 * dc.FontHeight()
+	roomWidth, roomHeight := w.width-2*w.opts.EdgeMargin, w.height-2*w.opts.EdgeMargin
+	if width <= 0 || height <= 0 || roomWidth <= 0 || roomHeight <= 0 {
+		return dc.Image()
+	}
+	pw.size = thumbnailMeasureSize * math.Min(roomWidth/width, roomHeight/height)
+
+	dc.SetFontFace(w.face(pw.text, pw.size))
+	pw.width, pw.height = w.measureString(dc, pw.text)
+	w.renderWord(dc, &pw, 0, 0)
+	return dc.Image()
+}
@@ -0,0 +1,35 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Thumbnail(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"thumbnail": 10, "other": 5},
+		BackgroundColor(color.White),
+		Width(300),
+		Height(100),
+		EdgeMargin(10),
+	)
+	img := w.Thumbnail("")
+	assert.Equal(t, image.Rect(0, 0, 300, 100), img.Bounds())
+
+	// The most frequent word fills the width of the canvas within the margins
+	drawn := image.Rectangle{}
+	for x := 0; x < 300; x++ {
+		for y := 0; y < 100; y++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r != 0xffff {
+				drawn = drawn.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	assert.GreaterOrEqual(t, drawn.Min.X, 10)
+	assert.GreaterOrEqual(t, drawn.Min.Y, 10)
+	assert.LessOrEqual(t, drawn.Max.X, 290)
+	assert.LessOrEqual(t, drawn.Max.Y, 90)
+	assert.Greater(t, drawn.Dx(), 250)
+}
@@ -0,0 +1,51 @@
+package wordclouds
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// TierStyle styles the words of an importance tier. Tiers are made of the words ranked by decreasing count.
+type TierStyle struct {
+	// Share of the words in the tier, e.g. 0.1 for the top 10%. The last tier gets all the remaining words.
+	Share float64
+	// Colors of the words of the tier. Colors is used when empty.
+	Colors []color.Color
+	// Multiplier of the font size of the words of the tier. No scaling when 0.
+	SizeScale float64
+}
+
+// assignTiers sets the tier of the words, which must be sorted by decreasing count
+func assignTiers(words []wordCount, tiers []TierStyle) {
+	if len(tiers) == 0 {
+		return
+	}
+	t := 0
+	end := tierEnd(0, tiers[0], len(words))
+	for idx := range words {
+		for idx >= end && t < len(tiers)-1 {
+			t++
+			end = tierEnd(end, tiers[t], len(words))
+		}
+		words[idx].tier = t
+	}
+}
+
+// tierEnd returns the index after the last word of a tier starting at start
+func tierEnd(start int, tier TierStyle, n int) int {
+	return start + int(math.Round(tier.Share*float64(n)))
+}
+
+// pickColor returns a random color for the word, among the ones of its tier if it has any. With ColorCycle, the colors
+// are taken in order instead, the next one for each placed word.
+func (w *Wordcloud) pickColor(wc wordCount) color.Color {
+	colors := w.opts.Colors
+	if wc.tier >= 0 && len(w.opts.TierStyles[wc.tier].Colors) > 0 {
+		colors = w.opts.TierStyles[wc.tier].Colors
+	}
+	if w.opts.ColorCycle {
+		return colors[len(w.placed)%len(colors)]
+	}
+	return colors[rand.Intn(len(colors))]
+}
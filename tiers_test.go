@@ -0,0 +1,44 @@
+package wordclouds
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_TierStyles(t *testing.T) {
+	words := make(map[string]int)
+	for i := 0; i < 10; i++ {
+		words[fmt.Sprintf("w%d", i)] = 100 - i
+	}
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	w := NewWordcloud(words,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		FontMinSize(10),
+		Width(600),
+		Height(600),
+		TierStyles([]TierStyle{
+			{Share: 0.1, Colors: []color.Color{red}, SizeScale: 2},
+			{Share: 0.3, Colors: []color.Color{green}},
+			{Colors: []color.Color{blue}},
+		}),
+	)
+
+	expectedTiers := []int{0, 1, 1, 1, 2, 2, 2, 2, 2, 2}
+	for i, wc := range w.sortedWordList {
+		assert.Equal(t, expectedTiers[i], wc.tier, wc.word)
+	}
+	assert.Equal(t, 80.0, w.sortedWordList[0].size)
+
+	w.Draw()
+	assert.Len(t, w.placed, 10)
+	tierColors := []color.Color{red, green, blue}
+	for _, pw := range w.placed {
+		assert.Equal(t, tierColors[pw.tier], pw.color, pw.word)
+	}
+}
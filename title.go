@@ -0,0 +1,47 @@
+package wordclouds
+
+import "image/color"
+
+// TitleOptions describes how the title of the cloud is drawn
+type TitleOptions struct {
+	Color color.Color
+	// Font size, defaults to 24
+	Size float64
+	// Fill of the band behind the title, transparent if nil
+	Background color.Color
+}
+
+// Font size of titles without a size
+const defaultTitleSize = 24
+
+func (w *Wordcloud) titleSize() float64 {
+	if w.opts.TitleOptions.Size <= 0 {
+		return defaultTitleSize
+	}
+	return w.opts.TitleOptions.Size
+}
+
+// titleBox returns the band reserved for the title at the top of the canvas, with half the font size of padding
+// around the text
+func (w *Wordcloud) titleBox() *Box {
+	size := w.titleSize()
+	w.setFont(w.opts.Title, size)
+	_, height := w.measureString(w.dc, w.opts.Title)
+	height += 0.3*w.dc.FontHeight() + size
+	return &Box{height, 0, w.width, 0}
+}
+
+func (w *Wordcloud) drawTitle() {
+	box := w.titleBox()
+	if w.opts.TitleOptions.Background != nil {
+		w.dc.SetColor(w.opts.TitleOptions.Background)
+		w.dc.DrawRectangle(box.x(), box.y(), box.w(), box.h())
+		w.dc.Fill()
+	}
+	c := w.opts.TitleOptions.Color
+	if c == nil {
+		c = color.Black
+	}
+	w.dc.SetColor(c)
+	w.drawString(w.dc, w.opts.Title, w.width/2, (box.h()-0.3*w.dc.FontHeight())/2)
+}
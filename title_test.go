@@ -0,0 +1,23 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Title(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		Title("Title", TitleOptions{Size: 30}),
+	)
+	w.Draw()
+
+	band := w.titleBox()
+	assert.Greater(t, band.Top, 30.0)
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		assert.GreaterOrEqual(t, pw.box.Bottom, band.Top, pw.word)
+	}
+}
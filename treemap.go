@@ -0,0 +1,129 @@
+package wordclouds
+
+import "math"
+
+// treemapRects partitions the canvas, within the edge margin, into rectangles of areas proportional to the counts of
+// the words, with the squarified treemap algorithm. Words without a positive count get no rectangle, and words
+// repeated by RepeatMode get a single one.
+func (w *Wordcloud) treemapRects() map[string]*Box {
+	m := w.opts.EdgeMargin
+	rect := Box{w.height - m, m, w.width - m, m}
+	words := make([]wordCount, 0, len(w.sortedWordList))
+	seen := make(map[string]bool, len(w.sortedWordList))
+	total := 0.0
+	for _, wc := range w.sortedWordList {
+		if wc.count <= 0 || seen[wc.word] {
+			continue
+		}
+		seen[wc.word] = true
+		words = append(words, wc)
+		total += float64(wc.count)
+	}
+
+	rects := make(map[string]*Box, len(words))
+	if total == 0 || rect.w() <= 0 || rect.h() <= 0 {
+		return rects
+	}
+	areas := make([]float64, len(words))
+	for i, wc := range words {
+		areas[i] = float64(wc.count) / total * rect.area()
+	}
+	for i, b := range squarify(areas, rect) {
+		rects[words[i].word] = b
+	}
+	return rects
+}
+
+// squarify lays out rectangles of the areas, sorted in decreasing order, in the rectangle. The rectangles are laid in
+// rows along the shorter side of the space left, each row growing as long as it brings their aspect ratios closer
+// to 1.
+func squarify(areas []float64, rect Box) []*Box {
+	res := make([]*Box, 0, len(areas))
+	for start := 0; start < len(areas); {
+		side := math.Min(rect.w(), rect.h())
+		end := start + 1
+		for end < len(areas) && worstRatio(areas[start:end+1], side) <= worstRatio(areas[start:end], side) {
+			end++
+		}
+		sum := 0.0
+		for _, a := range areas[start:end] {
+			sum += a
+		}
+		thickness := sum / side
+		offset := 0.0
+		for _, a := range areas[start:end] {
+			length := a / thickness
+			if rect.w() >= rect.h() {
+				// The row is a column on the left of the space left
+				res = append(res, &Box{rect.Bottom + offset + length, rect.Left, rect.Left + thickness,
+					rect.Bottom + offset})
+			} else {
+				res = append(res, &Box{rect.Bottom + thickness, rect.Left + offset, rect.Left + offset + length,
+					rect.Bottom})
+			}
+			offset += length
+		}
+		if rect.w() >= rect.h() {
+			rect.Left += thickness
+		} else {
+			rect.Bottom += thickness
+		}
+		start = end
+	}
+	return res
+}
+
+// worstRatio returns the largest aspect ratio of the rectangles of a row of areas laid along a side
+func worstRatio(row []float64, side float64) float64 {
+	sum, smallest, largest := 0.0, math.Inf(1), 0.0
+	for _, a := range row {
+		sum += a
+		smallest = math.Min(smallest, a)
+		largest = math.Max(largest, a)
+	}
+	sum2, side2 := sum*sum, side*side
+	return math.Max(side2*largest/sum2, sum2/(side2*smallest))
+}
+
+// placeTreemap places the word at the center of its rectangle of the treemap layout, shrunk until it fits the
+// rectangle. Returns false if the word has no rectangle or does not fit it at any size.
+func (w *Wordcloud) placeTreemap(wc wordCount) bool {
+	rect, ok := w.treemap[wc.word]
+	if !ok {
+		return false
+	}
+	wc.size = w.treemapSize(wc, rect)
+	if wc.size == 0 {
+		return false
+	}
+	placed := w.place(wc, func(width float64, height float64) (float64, float64, float64, bool) {
+		// The descent is below the box, the word and its descent are centered
+		return (rect.Left + rect.Right) / 2, (rect.Top+rect.Bottom)/2 - w.descent(wc.word)/2, 0, true
+	})
+	if placed {
+		delete(w.treemap, wc.word)
+	}
+	return placed
+}
+
+// treemapSize returns the size at which the word, its padding and its descent fit the rectangle, at most its own
+// size, or 0 if it does not fit at any size
+func (w *Wordcloud) treemapSize(wc wordCount, rect *Box) float64 {
+	text := w.text(wc)
+	padding := 5 + 2*w.padding(wc.word)
+	size := wc.size
+	factor := 1.0
+	for {
+		wc.size = size * factor
+		width, height := w.measureWord(w.dc, wc, text)
+		roomWidth := rect.w() - padding
+		roomHeight := rect.h() - padding - w.descent(wc.word)
+		if width <= roomWidth && height <= roomHeight {
+			return wc.size
+		}
+		if wc.size < 1 || roomWidth <= 0 || roomHeight <= 0 {
+			return 0
+		}
+		factor *= math.Min(oversizeStep, math.Min(roomWidth/width, roomHeight/height))
+	}
+}
@@ -0,0 +1,49 @@
+package wordclouds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_TreemapLayout(t *testing.T) {
+	words := map[string]int{"treemap": 40, "layout": 25, "rectangle": 15, "area": 10, "count": 6, "word": 4}
+	w := newTestCloud(t, words,
+		FontMaxSize(80),
+		FontMinSize(10),
+		Height(300),
+		TreemapLayout(true),
+	)
+	rects := w.treemapRects()
+	total := 0.0
+	for word, count := range words {
+		assert.InDelta(t, float64(count)/100*400*300, rects[word].area(), 1e-6, word)
+		total += rects[word].area()
+	}
+	assert.InDelta(t, 400*300, total, 1e-6)
+	w.Draw()
+
+	assert.Len(t, w.placed, len(words))
+	for _, pw := range w.placed {
+		rect := rects[pw.word]
+		assert.InDelta(t, (rect.Left+rect.Right)/2, pw.x, 1e-9, pw.word)
+		assert.True(t, pw.bounds.Left >= rect.Left && pw.bounds.Right <= rect.Right, pw.word)
+		assert.True(t, pw.bounds.Bottom >= rect.Bottom && pw.bounds.Top <= rect.Top, pw.word)
+	}
+
+	// Padded words are shrunk to fit their rectangle with their padding, whatever the case of WordPadding
+	padded := newTestCloud(t, words,
+		FontMaxSize(80),
+		FontMinSize(10),
+		Height(300),
+		TreemapLayout(true),
+		WordPadding(map[string]float64{"Treemap": 30}),
+	)
+	padded.Draw()
+	pw := padded.placed[0]
+	assert.Equal(t, "treemap", pw.word)
+	assert.Less(t, pw.size, w.placed[0].size)
+	rect := rects[pw.word]
+	assert.True(t, pw.box.Left >= rect.Left && pw.box.Right <= rect.Right)
+	assert.True(t, pw.box.Bottom >= rect.Bottom && pw.box.Top <= rect.Top)
+}
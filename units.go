@@ -0,0 +1,35 @@
+package wordclouds
+
+import "math"
+
+// Unit is a physical length unit
+type Unit int
+
+const (
+	// Inch is 25.4 millimeters
+	Inch Unit = iota
+	// Millimeter is a thousandth of a meter
+	Millimeter
+)
+
+// Millimeters in an inch
+const mmPerInch = 25.4
+
+// Resolution font sizes are given at when no DPI is set: a point is a pixel
+const defaultDPI = 72
+
+// ToPixels converts a length to pixels at the given resolution in dots per inch
+func ToPixels(length float64, unit Unit, dpi float64) int {
+	if unit == Millimeter {
+		length /= mmPerInch
+	}
+	return int(math.Round(length * dpi))
+}
+
+// pixelSize converts a font size in points to pixels
+func (w *Wordcloud) pixelSize(size float64) float64 {
+	if w.opts.DPI == 0 {
+		return size
+	}
+	return size * w.opts.DPI / defaultDPI
+}
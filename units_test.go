@@ -0,0 +1,56 @@
+package wordclouds
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_PhysicalSize(t *testing.T) {
+	words := map[string]int{"hello": 10, "world": 5}
+	printed := NewWordcloud(words,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		PhysicalSize(6, 4, Inch, 300),
+	)
+	assert.Equal(t, image.Rect(0, 0, 1800, 1200), printed.Draw().Bounds())
+
+	// Same cloud at 72 dpi, where a point is a pixel
+	screen := NewWordcloud(words,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		PhysicalSize(152.4, 101.6, Millimeter, 72),
+	)
+	assert.Equal(t, image.Rect(0, 0, 432, 288), screen.Draw().Bounds())
+
+	printWidth, _ := printed.MeasureWord("hello", 10)
+	screenWidth, _ := screen.MeasureWord("hello", 10)
+	assert.InEpsilon(t, 300.0/72, (printWidth-5)/(screenWidth-5), 0.02)
+}
+
+func TestWordcloud_PhysicalSizePixels(t *testing.T) {
+	strokeSizes := make([]float64, 0)
+	w := NewWordcloud(map[string]int{"hello": 10},
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(20),
+		Colors([]color.Color{color.Black}),
+		PhysicalSize(3, 3, Inch, 144),
+		BarStyle(FrequencyBar{MaxLength: 40, Thickness: 0.5}),
+		WordStroke(func(size float64) float64 {
+			strokeSizes = append(strokeSizes, size)
+			return 1
+		}, nil),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, 1)
+
+	// A point is two pixels at 144 dpi
+	pw := w.placed[0]
+	assert.Contains(t, strokeSizes, 40.0)
+	assert.NotContains(t, strokeSizes, 20.0)
+	_, _, _, thickness := w.barRect(&pw, pw.x, pw.y)
+	assert.Equal(t, 20.0, thickness)
+	assert.Equal(t, 40.0/432, w.PlacedWordsNormalized()[0].Size)
+}
@@ -0,0 +1,51 @@
+package wordclouds
+
+import "image/color"
+
+// Corner of the canvas
+type Corner int
+
+const (
+	TopLeft Corner = iota
+	TopRight
+	BottomLeft
+	BottomRight
+)
+
+// WatermarkOptions describes a text drawn in a corner of the canvas, such as an attribution
+type WatermarkOptions struct {
+	Text     string
+	Position Corner
+	Color    color.Color
+	Size     float64
+}
+
+// watermarkBox returns the box reserved for the watermark, half its font size away from the edges
+func (w *Wordcloud) watermarkBox() *Box {
+	wm := w.opts.Watermark
+	w.setFont(wm.Text, wm.Size)
+	width, height := w.measureString(w.dc, wm.Text)
+	height += 0.3 * w.dc.FontHeight()
+	padding := wm.Size / 2
+
+	left := padding
+	if wm.Position == TopRight || wm.Position == BottomRight {
+		left = w.width - padding - width
+	}
+	bottom := padding
+	if wm.Position == BottomLeft || wm.Position == BottomRight {
+		bottom = w.height - padding - height
+	}
+	return &Box{
+		bottom + height,
+		left,
+		left + width,
+		bottom,
+	}
+}
+
+func (w *Wordcloud) drawWatermark() {
+	box := w.watermarkBox()
+	w.dc.SetColor(w.opts.Watermark.Color)
+	w.drawString(w.dc, w.opts.Watermark.Text, box.Left+box.w()/2, box.Bottom+(box.h()-0.3*w.dc.FontHeight())/2)
+}
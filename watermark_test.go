@@ -0,0 +1,32 @@
+package wordclouds
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordcloud_Watermark(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	w := newTestCloud(t, nil,
+		Watermark("wordclouds", BottomRight, red, 20),
+	)
+	img := w.Draw()
+
+	reserved := w.watermarkBox()
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		assert.False(t, pw.box.overlaps(reserved), pw.word)
+	}
+
+	drawn := 0
+	for x := int(reserved.Left); x < int(reserved.Right); x++ {
+		for y := int(reserved.Bottom); y < int(reserved.Top); y++ {
+			if img.At(x, y) == color.Color(red) {
+				drawn++
+			}
+		}
+	}
+	assert.Greater(t, drawn, 0)
+}
@@ -1,7 +1,9 @@
 package wordclouds
 
 import (
+	"fmt"
 	"image"
+	"image/color"
 	"math"
 	"math/rand"
 	"runtime"
@@ -11,19 +13,46 @@ import (
 	"time"
 
 	"github.com/fogleman/gg"
-	"golang.org/x/image/font"
 )
 
 type wordCount struct {
 	word  string
 	count int
 	size  float64
+	// Index in TierStyles, -1 without tiers
+	tier int
+}
+
+// A word drawn on the canvas
+type placedWord struct {
+	wordCount
+	// What is drawn for the word
+	text string
+	x    float64
+	y    float64
+	// Rotation in degrees, counterclockwise
+	angle float64
+	color color.Color
+	// Box the word was placed in
+	box *Box
+	// Box the drawn word fits in, including descenders
+	bounds *Box
+	// Boxes the word occupies in the collision grid
+	boxes []*Box
+	// Whether the boxes are outlined on the canvas, with Debug
+	outlined bool
+	// Size of the box before rotation
+	width  float64
+	height float64
+	// Length of the frequency bar drawn on the right of the word
+	bar float64
 }
 
 // Wordcloud object. Create one with NewWordcloud and use Draw() to get the image
 type Wordcloud struct {
 	wordList        map[string]int
 	sortedWordList  []wordCount
+	maxCount        int
 	grid            *spatialHashMap
 	dc              *gg.Context
 	randomPlacement bool
@@ -31,8 +60,54 @@ type Wordcloud struct {
 	height          float64
 	opts            Options
 	circles         map[float64]*circle
-	fonts           map[float64]font.Face
+	fonts           map[fontKey]*fontFace
+	ttfs            map[string]*ttf
+	providedFaces   map[float64]*fontFace
+	fontsMu         sync.Mutex
+	prefetchFonts   bool
 	radii           []float64
+	placed          []placedWord
+	// Reflections of the placed words with MirrorSymmetry, drawn as the words are placed
+	reflections []placedWord
+	// Areas covered by masks and words, overlaps included
+	maskArea  float64
+	wordsArea float64
+	result    DrawResult
+	drawn     bool
+	// Number of words placed without collision tests
+	unchecked int
+	// Colors of the words overriding the palette
+	wordColors map[string]color.Color
+	// Statistics of the ongoing placement, for Telemetry
+	attempt attempt
+	// Placement attempts since the last Reset, with RecordAttempts
+	attempts []PlacementAttempt
+	// Occupied cells of the grid layout, by column then row
+	cells      [][]bool
+	pngBuffers pngBuffers
+	// Background gradient, drawn over the background color
+	background image.Image
+	// Factor applied to all the word sizes so that the largest word fits, see ScaleAllOversize
+	sizeFactor float64
+	// Shifts of the spiral by group of words, and the one of the word being placed
+	groupOffsets map[string]point
+	offset       point
+	// Room for the descenders of the word being placed
+	placingDescent float64
+	// WordPadding by lowercase word
+	paddings map[string]float64
+	// Rectangles of the words not placed yet in the treemap layout
+	treemap map[string]*Box
+}
+
+// DrawResult summarizes the outcome of Draw
+type DrawResult struct {
+	// Words placed on the canvas, in placement order
+	Placed []string
+	// Words that could not be placed
+	Skipped []string
+	// Positions tried by the random placement
+	RandomTries int
 }
 
 // Initialize a wordcloud based on a map of word frequency.
@@ -42,80 +117,264 @@ func NewWordcloud(wordList map[string]int, options ...Option) *Wordcloud {
 		opt(&opts)
 	}
 
-	sortedWordList := make([]wordCount, 0, len(wordList))
-	for word, count := range wordList {
+	dc := opts.Context
+	if dc == nil {
+		dc = gg.NewContext(opts.Width, opts.Height)
+	} else if dc.Width() != opts.Width || dc.Height() != opts.Height {
+		panic(fmt.Sprintf("context size %dx%d does not match the canvas size %dx%d",
+			dc.Width(), dc.Height(), opts.Width, opts.Height))
+	}
+
+	if opts.MaskSVGPath != "" {
+		boxes, err := svgMask(opts.MaskSVGPath, opts.MaskSVGWidth, opts.MaskSVGHeight, opts.Width, opts.Height)
+		if err != nil {
+			panic(err)
+		}
+		opts.Mask = append(append(make([]*Box, 0, len(opts.Mask)+len(boxes)), opts.Mask...), boxes...)
+	}
+
+	radius := 1.0
+	maxRadius := math.Sqrt(float64(opts.Width*opts.Width + opts.Height*opts.Height))
+	circles := make(map[float64]*circle)
+	radii := make([]float64, 0)
+	cx, cy := opts.spiralCenter()
+	for radius < maxRadius {
+		if radius >= opts.MinRadius && (opts.MaxRadius <= 0 || radius <= opts.MaxRadius) {
+			circles[radius] = newCircle(cx, cy, radius, 512)
+			radii = append(radii, radius)
+		}
+		radius = radius + 5.0
+	}
+
+	rand.Seed(time.Now().UnixNano())
+
+	w := &Wordcloud{
+		dc:              dc,
+		randomPlacement: opts.RandomPlacement,
+		width:           float64(opts.Width),
+		height:          float64(opts.Height),
+		opts:            opts,
+		circles:         circles,
+		fonts:           make(map[fontKey]*fontFace),
+		ttfs:            make(map[string]*ttf),
+		providedFaces:   make(map[float64]*fontFace),
+		prefetchFonts:   true,
+		radii:           radii,
+	}
+	w.groupOffsets = groupOffsets(&opts)
+	if len(opts.BackgroundGradient) > 0 {
+		w.background = gradientImage(opts.Width, opts.Height, opts.BackgroundGradient, opts.GradientKind)
+	}
+	// The palette is only generated when none is given: the default one is a single transparent color
+	if opts.AutoPalette > 0 && len(opts.Colors) == 1 && opts.Colors[0] == defaultOptions.Colors[0] {
+		var bg color.Color = opts.BackgroundColor
+		if w.background != nil {
+			bg = averageColor(w.background)
+		}
+		w.opts.Colors = autoPalette(opts.AutoPalette, bg)
+	}
+	w.Reset(wordList)
+	return w
+}
+
+// NewWordcloudFromTokens counts the occurrences of already split words and creates a cloud of them like NewWordcloud.
+// The tokens are normalized with the Normalizer of TokenOptions, if any, and can be filtered with ExcludeWords.
+func NewWordcloudFromTokens(tokens []string, options ...Option) *Wordcloud {
+	opts := defaultOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	counts := countOptions{
+		normalizer: func(s string) string { return s },
+	}
+	for _, opt := range opts.TokenOptions {
+		opt(&counts)
+	}
+
+	wordList := make(map[string]int)
+	counts.countTokens(wordList, tokens)
+	return NewWordcloud(wordList, options...)
+}
+
+// Reset replaces the words of the cloud and clears the canvas, so that Draw lays out the new words. The context,
+// placement circles and fonts are kept, which makes rendering many clouds cheaper. Options cannot be changed.
+func (w *Wordcloud) Reset(wordList map[string]int) {
+	opts := &w.opts
+	excluded := make(map[string]bool, len(opts.ExcludeWords))
+	for _, word := range opts.ExcludeWords {
+		excluded[strings.ToLower(strings.Trim(word, " "))] = true
+	}
+	words := wordList
+	if opts.MergeCaseVariants {
+		words = mergeCaseVariants(wordList)
+	}
+	sortedWordList := make([]wordCount, 0, len(words))
+	for word, count := range words {
+		if count <= 0 && opts.NonPositiveCounts == SkipNonPositive {
+			continue
+		}
+		if excluded[strings.ToLower(strings.Trim(word, " "))] {
+			continue
+		}
 		sortedWordList = append(sortedWordList, wordCount{
 			word:  strings.Trim(word, " "),
 			count: count,
 			size:  5,
+			tier:  -1,
 		})
 
 	}
+	// Break ties by word so that the layout does not depend on map ordering
 	sort.Slice(sortedWordList, func(i, j int) bool {
+		if sortedWordList[i].count == sortedWordList[j].count {
+			return sortedWordList[i].word < sortedWordList[j].word
+		}
 		return sortedWordList[i].count > sortedWordList[j].count
 	})
 
-	wordCountMax := float64(sortedWordList[0].count)
+	assignTiers(sortedWordList, opts.TierStyles)
 
+	maxCount := 0
+	if len(sortedWordList) > 0 {
+		maxCount = sortedWordList[0].count
+	}
 	for idx := range sortedWordList {
 		word := &sortedWordList[idx]
-		word.size =
-			opts.SizeFunction(float64(word.count)/wordCountMax) *
-				float64(opts.FontMaxSize)
-		if word.size < float64(opts.FontMinSize) {
-			word.size = float64(opts.FontMinSize)
-		}
+		word.size = wordSize(opts, word.count, maxCount, word.tier)
+		word.size = math.Max(word.size*w.lengthFactor(word.word), float64(opts.FontMinSize))
+	}
+	if opts.RepeatMode > 0 {
+		sortedWordList = repeatWords(sortedWordList, opts)
+	}
+	for idx := range sortedWordList {
+		sortedWordList[idx].size = w.snapSize(sortedWordList[idx].size)
 	}
 
-	dc := gg.NewContext(opts.Width, opts.Height)
-	dc.SetColor(opts.BackgroundColor)
-	dc.Clear()
+	dc := w.dc
+	w.clearBackground(dc)
 	dc.SetRGB(0, 0, 0)
-	grid := newSpatialHashMap(float64(opts.Width), float64(opts.Height), opts.Height/10)
 
+	maskArea := 0.0
 	for _, b := range opts.Mask {
 		if opts.Debug {
 			dc.DrawRectangle(b.x(), b.y(), b.w(), b.h())
 			dc.Stroke()
 		}
-		grid.Add(b)
+		maskArea += b.clip(float64(opts.Width), float64(opts.Height)).area()
 	}
 
-	radius := 1.0
-	maxRadius := math.Sqrt(float64(opts.Width*opts.Width + opts.Height*opts.Height))
-	circles := make(map[float64]*circle)
-	radii := make([]float64, 0)
-	for radius < maxRadius {
-		circles[radius] = newCircle(float64(opts.Width/2), float64(opts.Height/2), radius, 512)
-		radii = append(radii, radius)
-		radius = radius + 5.0
+	w.wordList = wordList
+	w.sortedWordList = sortedWordList
+	w.maxCount = maxCount
+	w.maskArea = maskArea
+	w.wordsArea = 0
+	w.placed = nil
+	w.reflections = nil
+	w.attempts = nil
+	w.result = DrawResult{}
+	w.drawn = false
+	w.unchecked = 0
+	w.resetCells()
+	w.paddings = make(map[string]float64, len(opts.WordPadding))
+	for word, padding := range opts.WordPadding {
+		w.paddings[strings.ToLower(word)] = padding
+	}
+	w.sizeFactor = w.fitLargestWord()
+	if opts.Treemap {
+		w.treemap = w.treemapRects()
 	}
+	w.resetGrid()
+	if opts.Title != "" {
+		w.maskArea += w.titleBox().clip(w.width, w.height).area()
+	}
+}
 
-	rand.Seed(time.Now().UnixNano())
+// resetGrid empties the collision grid, leaving the masks, the watermark and the title band
+func (w *Wordcloud) resetGrid() {
+	w.grid = newSpatialHashMap(w.width, w.height, w.gridCells())
+	for _, b := range w.opts.Mask {
+		w.grid.AddMask(b)
+	}
+	if w.opts.Watermark.Text != "" {
+		w.grid.AddMask(w.watermarkBox())
+	}
+	if w.opts.Title != "" {
+		w.grid.AddMask(w.titleBox())
+	}
+}
 
-	return &Wordcloud{
-		wordList:        wordList,
-		sortedWordList:  sortedWordList,
-		grid:            grid,
-		dc:              dc,
-		randomPlacement: opts.RandomPlacement,
-		width:           float64(opts.Width),
-		height:          float64(opts.Height),
-		opts:            opts,
-		circles:         circles,
-		fonts:           make(map[float64]font.Face),
-		radii:           radii,
+// wordSize returns the font size of a word from its count and tier
+func wordSize(opts *Options, count int, maxCount int, tier int) float64 {
+	if count <= 0 {
+		// Only kept with ClampNonPositive
+		return float64(opts.FontMinSize)
+	}
+	size := opts.SizeFunction(float64(count)/float64(maxCount)) * float64(opts.FontMaxSize)
+	if tier >= 0 && opts.TierStyles[tier].SizeScale != 0 {
+		size *= opts.TierStyles[tier].SizeScale
+	}
+	return math.Max(size, float64(opts.FontMinSize))
+}
+
+// gridCells returns the number of cells of the spatial hash map along each axis
+func (w *Wordcloud) gridCells() int {
+	cellSize := float64(w.opts.GridCellSize)
+	if cellSize <= 0 && w.opts.AutoGridCellSize {
+		cellSize = w.medianWordDimension()
+	}
+	if cellSize <= 0 {
+		return max(w.opts.Height/10, 1)
+	}
+	return max(int(math.Ceil(math.Max(w.width, w.height)/cellSize)), 1)
+}
+
+// medianWordDimension measures the words and returns the median of their widths and heights
+func (w *Wordcloud) medianWordDimension() float64 {
+	dims := make([]float64, 0, 2*len(w.sortedWordList))
+	for _, wc := range w.sortedWordList {
+		width, height := w.measureWord(w.dc, wc, w.text(wc))
+		dims = append(dims, width, height)
+	}
+	if len(dims) == 0 {
+		return 0
+	}
+	sort.Float64s(dims)
+	return dims[len(dims)/2]
+}
+
+// preciseBoxes returns boxes following the shape of a large placed word: the boxes of the glyph outlines for text, the
+// whole box for a word on a chip and the non-background pixels of the drawn canvas for an icon or the text of a
+// FontProvider
+func (w *Wordcloud) preciseBoxes(pw *placedWord, bounds *Box) []*Box {
+	if w.chipColor(pw.word) != nil && w.icon(pw.word) == nil {
+		return []*Box{bounds}
+	}
+	// The glyph outlines are only known for font files
+	if w.icon(pw.word) == nil && w.opts.FontProvider == nil {
+		return w.outlineBoxes(pw)
 	}
+	boxes := w.getPreciseBoundingBoxes(bounds)
+	if padding := w.padding(pw.word); padding > 0 {
+		for _, b := range boxes {
+			b.Top, b.Left, b.Right, b.Bottom = b.Top+padding, b.Left-padding, b.Right+padding, b.Bottom-padding
+		}
+	}
+	return boxes
 }
 
 func (w *Wordcloud) getPreciseBoundingBoxes(b *Box) []*Box {
 	res := make([]*Box, 0)
-	step := 5
+	step := w.opts.PreciseScanStep
+	if step < 1 {
+		step = 1
+	}
+	if w.opts.PreciseScanChunk > 0 {
+		return w.scanChunks(b, step)
+	}
 
-	defColor := w.opts.BackgroundColor
 	for i := int(math.Floor(b.Left)); i < int(b.Right); i = i + step {
 		for j := int(b.Bottom); j < int(b.Top); j = j + step {
-			if w.dc.Image().At(i, j) != defColor {
+			if w.isDrawn(i, j) {
 				res = append(res, &Box{
 					float64(j+step) + 5,
 					float64(i) - 5,
@@ -128,92 +387,384 @@ func (w *Wordcloud) getPreciseBoundingBoxes(b *Box) []*Box {
 	return res
 }
 
-func (w *Wordcloud) setFont(size float64) {
-	_, ok := w.fonts[size]
+// dominantSpace returns the empty space to reserve around a word, if it is large enough to be dominant
+func (w *Wordcloud) dominantSpace(wc wordCount) float64 {
+	if w.opts.DominantWordSpace <= 0 || wc.size < w.opts.DominantWordMinSize {
+		return 0
+	}
+	return w.opts.DominantWordSpace * wc.size
+}
 
-	if !ok {
-		f, err := gg.LoadFontFace(w.opts.FontFile, size)
-		if err != nil {
-			panic(err)
-		}
-		w.fonts[size] = f
+// text returns what is drawn on the canvas for a word
+func (w *Wordcloud) text(wc wordCount) string {
+	word := wc.word
+	if w.opts.DisplayTransform != nil {
+		word = w.opts.DisplayTransform(word)
 	}
+	if w.opts.ShowCounts {
+		return fmt.Sprintf("%s (%d)", word, wc.count)
+	}
+	return word
+}
 
-	w.dc.SetFontFace(w.fonts[size])
+// fitsCanvas tells whether a word box of the given size fits on the empty canvas, in any of the orientations it may
+// be drawn with. Words too large for the canvas are skipped without searching for a position.
+func (w *Wordcloud) fitsCanvas(width float64, height float64) bool {
+	roomWidth, roomHeight := w.width-2*w.opts.EdgeMargin, w.height-2*w.opts.EdgeMargin
+	if w.opts.RadialLayout {
+		return math.Max(width, height) <= math.Hypot(roomWidth, roomHeight)
+	}
+	if width <= roomWidth && height <= roomHeight {
+		return true
+	}
+	return w.opts.RotateToFit && height <= roomWidth && width <= roomHeight
 }
 
+// Place finds a position for the word and draws it. Returns false if there is no room left for the word.
 func (w *Wordcloud) Place(wc wordCount) bool {
-	c := w.opts.Colors[rand.Intn(len(w.opts.Colors))]
+	return w.track(wc, w.placeAnywhere)
+}
+
+// track places the word with the placement function, reporting the attempt to Telemetry and RecordAttempts
+func (w *Wordcloud) track(wc wordCount, placement func(wordCount) bool) (placed bool) {
+	if w.opts.Telemetry != nil || w.opts.RecordAttempts {
+		start := time.Now()
+		w.attempt = attempt{radius: -1}
+		defer func() {
+			if w.opts.Telemetry != nil {
+				w.opts.Telemetry(wc.word, w.attempt.tries, w.attempt.radius, time.Since(start))
+			}
+			if w.opts.RecordAttempts {
+				radius := w.attempt.radius
+				if !placed {
+					radius = -1
+				}
+				w.attempts = append(w.attempts, PlacementAttempt{wc.word, wc.size, w.attempt.tries, radius, placed})
+			}
+		}()
+	}
+	return placement(wc)
+}
+
+// placeAnywhere places the word with the position function of the layout
+func (w *Wordcloud) placeAnywhere(wc wordCount) bool {
+	if w.opts.Treemap {
+		return w.placeTreemap(wc)
+	}
+	if w.opts.NoCollision {
+		return w.place(wc, w.nextUnchecked)
+	}
+	w.offset = w.groupOffset(wc.word)
+	if w.opts.GridCols > 0 && w.opts.GridRows > 0 {
+		return w.place(wc, w.nextCell)
+	}
+	if w.opts.RiverWavelength > 0 {
+		return w.place(wc, w.nextRiver)
+	}
+	return w.place(wc, w.nextPos)
+}
+
+// A function finding a position for a word box of the given size
+type positionFunc func(width float64, height float64) (x float64, y float64, angle float64, space bool)
+
+func (w *Wordcloud) place(wc wordCount, position positionFunc) bool {
+	c := w.wordColor(wc)
 	w.dc.SetColor(c)
 
-	w.setFont(wc.size)
-	width, height := w.dc.MeasureString(wc.word)
+	text := w.text(wc)
+	width, height := w.measureWord(w.dc, wc, text)
 
-	width += 5
-	height += 5
-	x, y, space := w.nextPos(width, height)
+	padding := w.padding(wc.word)
+	width += 5 + 2*padding
+	height += 5 + 2*padding
+	if !w.fitsCanvas(width, height) {
+		return false
+	}
+	// Leave room for the descenders of the last line
+	descent := w.descent(wc.word)
+	w.placingDescent = descent
+	x, y, angle, space := position(width, height)
 	if !space {
 		return false
 	}
-	w.dc.DrawStringAnchored(wc.word, x, y, 0.5, 0.5)
 
-	box := &Box{
-		y + height/2 + 0.3*height,
-		x - width/2,
-		x + width/2,
-		math.Max(y-height/2, 0),
+	pw := placedWord{
+		wordCount: wc,
+		text:      text,
+		x:         x,
+		y:         y,
+		angle:     angle,
+		color:     c,
+		width:     width,
+		height:    height,
+		bar:       w.barLength(wc),
+		box: &Box{
+			y + height/2,
+			x - width/2,
+			x + width/2,
+			y - height/2,
+		},
+	}
+	switch angle {
+	case 0:
+		pw.bounds = &Box{
+			pw.box.Top + descent,
+			pw.box.Left,
+			pw.box.Right,
+			math.Max(pw.box.Bottom, 0),
+		}
+	case 90:
+		// The word reads upwards, the bottom of the letters is on the right
+		pw.box = &Box{
+			y + width/2,
+			x - height/2,
+			x + height/2,
+			y - width/2,
+		}
+		pw.bounds = &Box{
+			pw.box.Top,
+			pw.box.Left,
+			pw.box.Right + descent,
+			math.Max(pw.box.Bottom, 0),
+		}
+	default:
+		pw.box = cornersBox(rotatedCorners(x, y, x-width/2, y-height/2, x+width/2, y+height/2, angle))
+		pw.bounds = cornersBox(rotatedCorners(x, y, x-width/2, y-height/2, x+width/2, y+height/2+descent, angle))
+		pw.bounds.Bottom = math.Max(pw.bounds.Bottom, 0)
 	}
-	if height > 40 {
-		preciseBoxes := w.getPreciseBoundingBoxes(box)
+	if w.opts.ColorByRadius != nil {
+		pw.color = w.wordOpacity(w.radiusColor(x, y), wc)
+	}
+	if w.opts.EdgeFade > 0 {
+		pw.color = w.fadeColor(pw.color, x, y)
+	}
+	if w.opts.AutoContrast {
+		pw.color = w.contrastColor(pw.color, pw.box)
+	}
+	w.renderWord(w.dc, &pw, 0, 0)
+
+	box := pw.bounds
+	if w.opts.NoCollision {
+		// Nothing is tested against the grid
+		pw.boxes = []*Box{box}
+	} else if space := w.dominantSpace(wc); space > 0 {
+		// Keep the surroundings of dominant words empty
+		pw.boxes = []*Box{{
+			box.Top + space,
+			math.Max(box.Left-space, 0),
+			box.Right + space,
+			math.Max(box.Bottom-space, 0),
+		}}
+		w.grid.Add(pw.boxes[0])
+	} else if height > 40 {
+		preciseBoxes := w.preciseBoxes(&pw, box)
+		pw.boxes = preciseBoxes
+		pw.outlined = w.opts.Debug
 		for _, pb := range preciseBoxes {
 			w.grid.Add(pb)
-			if w.opts.Debug {
-				w.dc.DrawRectangle(pb.x(), pb.y(), pb.w(), pb.h())
-				w.dc.Stroke()
-			}
+		}
+		if pw.outlined {
+			w.outline(pw.boxes)
+		}
+	} else if angle != 0 && angle != 90 {
+		corners := rotatedCorners(x, y, x-width/2, y-height/2, x+width/2, y+height/2+descent, angle)
+		pw.boxes = stripBoxes(corners, math.Min(width, height)/2)
+		for _, b := range pw.boxes {
+			w.grid.Add(b)
 		}
 	} else {
+		pw.boxes = []*Box{box}
 		w.grid.Add(box)
 	}
+	w.placed = append(w.placed, pw)
+	w.wordsArea += pw.box.area()
+	if w.opts.Mirror {
+		w.reflections = append(w.reflections, w.reflect(pw))
+	}
 	return true
 }
 
-// Draw tries to place words one by one, starting with the ones with the highest counts
+// descent returns the room left below a word for the descenders of its last line, at the font size last measured
+func (w *Wordcloud) descent(word string) float64 {
+	if w.icon(word) != nil {
+		return 0
+	}
+	return 0.3 * (w.dc.FontHeight() + 5)
+}
+
+// Size factor applied to skipped words on each retry pass
+const retrySizeFactor = 0.8
+
+// Draw tries to place words one by one, starting with the ones with the highest counts.
+// Words that did not fit are retried at a reduced size if RetryPasses is set.
 func (w *Wordcloud) Draw() image.Image {
+	// Moved words are drawn again over the canvas as it is before any word
+	var base image.Image
+	if w.opts.Anchor != AnchorCenter || w.opts.SphereStrength > 0 {
+		base = w.snapshot()
+	}
+	minSize := float64(w.opts.FontMinSize)
+	words := w.sortedWordList
+	var centerSkipped []wordCount
+	if w.opts.CenterWord != "" {
+		words, centerSkipped = w.placeCenterWord(words)
+	}
+	skipped, untried := w.placeWords(words)
+	skipped = append(centerSkipped, skipped...)
+
+	for pass := 0; pass < w.opts.RetryPasses; pass++ {
+		retry := make([]wordCount, 0, len(skipped)+len(untried))
+		dropped := make([]wordCount, 0)
+		for _, wc := range skipped {
+			// The canvas only gets fuller, a word that did not fit can only fit if it gets smaller
+			if wc.size <= minSize {
+				dropped = append(dropped, wc)
+				continue
+			}
+			wc.size = w.snapDown(math.Max(wc.size*retrySizeFactor, minSize))
+			retry = append(retry, wc)
+		}
+		retry = append(retry, untried...)
+		if len(retry) == 0 {
+			break
+		}
+		sort.SliceStable(retry, func(i, j int) bool {
+			return retry[i].size > retry[j].size
+		})
+		skipped, untried = w.placeWords(retry)
+		skipped = append(dropped, skipped...)
+	}
+	w.mirrorWords()
+	skipped = append(skipped, w.projectWords(base)...)
+	w.anchorWords(base)
+
+	w.result.Placed = make([]string, 0, len(w.placed))
+	w.result.Skipped = make([]string, 0, len(skipped)+len(untried))
+	for _, pw := range w.placed {
+		w.result.Placed = append(w.result.Placed, pw.word)
+	}
+	for _, wc := range append(skipped, untried...) {
+		w.result.Skipped = append(w.result.Skipped, wc.word)
+	}
+	if len(w.opts.Relations) > 0 {
+		w.drawRelations()
+	}
+	if w.opts.Watermark.Text != "" {
+		w.drawWatermark()
+	}
+	if w.opts.Title != "" {
+		w.drawTitle()
+	}
+	w.drawn = true
+	return w.output()
+}
+
+// output applies the post-processing options to the canvas
+func (w *Wordcloud) output() image.Image {
+	var img image.Image = w.dc.Image()
+	if w.opts.BoxesOnly {
+		img = w.boxesImage()
+	}
+	if w.opts.CircleCrop {
+		img = circleCrop(img, w.cropOutside())
+	}
+	if w.opts.QuantizePalette {
+		p := w.palette()
+		if w.opts.CircleCrop && !paletteContains(p, color.Transparent) {
+			p = append(p, color.Transparent)
+		}
+		img = quantize(img, p)
+	}
+	if w.opts.ColorModel != nil {
+		img = convertImage(img, w.opts.ColorModel)
+	}
+	if _, ok := img.(*image.RGBA); w.opts.PremultipliedAlpha && !ok {
+		img = convertImage(img, color.RGBAModel)
+	}
+	return img
+}
+
+// placeWords places the words in order. It returns the words that did not fit, and the ones that were not tried
+// because too many words in a row did not fit.
+func (w *Wordcloud) placeWords(words []wordCount) (skipped []wordCount, untried []wordCount) {
+	if w.prefetchFonts {
+		done := make(chan struct{})
+		defer close(done)
+		go w.preloadFonts(words, done)
+	}
+
+	skipped = make([]wordCount, 0)
 	consecutiveMisses := 0
-	for _, wc := range w.sortedWordList {
+	for i, wc := range words {
 		success := w.Place(wc)
 		if !success {
+			if w.opts.OnWordDropped != nil {
+				w.opts.OnWordDropped(wc.word, wc.count, wc.size)
+			}
+			skipped = append(skipped, wc)
 			consecutiveMisses++
 			if consecutiveMisses > 10 {
-				return w.dc.Image()
+				return skipped, words[i+1:]
 			}
 			continue
 		}
 		consecutiveMisses = 0
 	}
-	return w.dc.Image()
+	return skipped, nil
+}
+
+// Result reports which words were placed and which were skipped by the last call to Draw
+func (w *Wordcloud) Result() DrawResult {
+	return w.result
+}
+
+// withDescent returns the box of a word grown by the room for the descenders of the word being placed: at the top of
+// the box for upright words, on the right for words reading upwards
+func (w *Wordcloud) withDescent(b *Box, angle float64) *Box {
+	if angle == 90 {
+		return &Box{b.Top, b.Left, b.Right + w.placingDescent, b.Bottom}
+	}
+	return &Box{b.Top + w.placingDescent, b.Left, b.Right, b.Bottom}
+}
+
+// padding returns the WordPadding of the word, matched regardless of case
+func (w *Wordcloud) padding(word string) float64 {
+	if padding, ok := w.opts.WordPadding[word]; ok {
+		return padding
+	}
+	return w.paddings[strings.ToLower(word)]
 }
 
 func (w *Wordcloud) nextRandom(width float64, height float64) (x float64, y float64, space bool) {
 	tries := 0
+	defer func() {
+		w.result.RandomTries += tries
+		w.attempt.tries += tries
+		if space {
+			w.attempt.radius = math.Hypot(x-w.width/2, y-w.height/2)
+		}
+	}()
+	maxTries := w.opts.RandomMaxTries
+	if maxTries <= 0 {
+		// The fuller the canvas, the less likely a free position is found
+		maxTries = int(math.Max(defaultRandomMaxTries*(1-w.fill()), minRandomMaxTries))
+	}
+
 	searching := true
 	var box Box
-	for searching && tries < 5000000 {
+	for searching && tries < maxTries {
 		tries++
-		x, y = float64(rand.Intn(w.dc.Width())), float64(rand.Intn(w.dc.Height()))
+		x, y = float64(rand.Intn(w.dc.Width())), w.bandY(float64(rand.Intn(w.dc.Height())))
 		// Is that position available?
 		box.Top = y + height/2
 		box.Left = x - width/2
 		box.Right = x + width/2
 		box.Bottom = y - height/2
 
-		if !box.fits(w.width, w.height) {
+		if !w.fits(w.withDescent(&box, 0)) {
 			continue
 		}
-		colliding, _ := w.grid.TestCollision(&box, func(a *Box, b *Box) bool {
-			return a.overlaps(b)
-		})
+		colliding := w.collides(&box)
 
 		if !colliding {
 			space = true
@@ -224,6 +775,32 @@ func (w *Wordcloud) nextRandom(width float64, height float64) (x float64, y floa
 	return
 }
 
+// Tries of random placement on an empty canvas and a full one, when RandomMaxTries is not set
+const (
+	defaultRandomMaxTries = 100000
+	minRandomMaxTries     = 1000
+)
+
+// fill returns the approximate share of the canvas covered by masks and words
+func (w *Wordcloud) fill() float64 {
+	return math.Min((w.maskArea+w.wordsArea)/(w.width*w.height), 1)
+}
+
+// FillRatio returns the approximate share of the area left free by masks that is covered by words, from 0 to 1.
+// After Draw, it helps deciding whether more words can be added or the canvas should be enlarged.
+func (w *Wordcloud) FillRatio() float64 {
+	free := w.width*w.height - w.maskArea
+	if free <= 0 {
+		return 1
+	}
+	return math.Min(w.wordsArea/free, 1)
+}
+
+// IsFull reports whether the last call to Draw had to skip words for lack of room
+func (w *Wordcloud) IsFull() bool {
+	return len(w.result.Skipped) > 0
+}
+
 // Data sent to placement workers
 type workerData struct {
 	radius    float64
@@ -238,17 +815,26 @@ type res struct {
 	x      float64
 	y      float64
 	failed bool
+	// Rotation of the word in degrees, counterclockwise
+	angle float64
+	// Number of positions tested
+	tries int
 }
 
 // Multithreaded word placement
-func (w *Wordcloud) nextPos(width float64, height float64) (x float64, y float64, space bool) {
+// angle is the rotation of the word in degrees, counterclockwise.
+func (w *Wordcloud) nextPos(width float64, height float64) (x float64, y float64, angle float64, space bool) {
 	if w.randomPlacement {
-		return w.nextRandom(width, height)
+		x, y, space = w.nextRandom(width, height)
+		return
 	}
 
 	space = false
 
 	x, y = w.width, w.height
+	if len(w.radii) == 0 {
+		return
+	}
 
 	stopSendingCh := make(chan struct{}, 1)
 	aggCh := make(chan res, 100)
@@ -326,10 +912,25 @@ func (w *Wordcloud) nextPos(width float64, height float64) (x float64, y float64
 		wg.Wait()
 	}()
 
+	var timeout <-chan time.Time
+	if w.opts.WordTimeout > 0 {
+		timer := time.NewTimer(w.opts.WordTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
 	// Finally, aggregate the results coming from workers
-	for d := range aggCh {
+	for {
+		var d res
+		select {
+		case d = <-aggCh:
+		case <-timeout:
+			// The word takes too long to place, skip it
+			return
+		}
 		results[d.radius] = d
 		done[d.radius] = true
+		w.attempt.tries += d.tries
 		//check if we need to continue
 		failed := true
 		// Example: if we know that there's a successful placement at r=10 but have not received results for r=5,
@@ -342,7 +943,8 @@ func (w *Wordcloud) nextPos(width float64, height float64) (x float64, y float64
 			}
 			// We have the successful placement with the lowest radius
 			if !results[r].failed {
-				return results[r].x, results[r].y, true
+				w.attempt.radius = r
+				return results[r].x, results[r].y, results[r].angle, true
 			}
 		}
 
@@ -350,19 +952,80 @@ func (w *Wordcloud) nextPos(width float64, height float64) (x float64, y float64
 		if failed {
 			return
 		}
+	}
+}
+
+// testRotated tells whether a word box rotated by angle degrees fits at x, y
+func (w *Wordcloud) testRotated(x float64, y float64, width float64, height float64, angle float64) bool {
+	corners := rotatedCorners(x, y, x-width/2, y-height/2, x+width/2, y+height/2, angle)
+	bounds := rotatedCorners(x, y, x-width/2, y-height/2, x+width/2, y+height/2+w.placingDescent, angle)
+	if !w.fits(cornersBox(bounds)) {
+		return false
+	}
+	for _, b := range stripBoxes(corners, math.Min(width, height)/2) {
+		colliding := w.collides(b)
+		if colliding {
+			return false
+		}
+	}
+	return true
+}
 
+// collides tells whether the box collides with the words and masks in the grid. With MirrorSymmetry, the reflection
+// of the box is tested too, against the masks, the title, the watermark and the reflections in the right half.
+func (w *Wordcloud) collides(b *Box) bool {
+	if w.opts.Mirror && w.collidesAt(w.mirrorBox(b)) {
+		return true
 	}
-	return
+	return w.collidesAt(b)
 }
 
-// test a series of points on a circle and returns as soon as there's a match
+func (w *Wordcloud) collidesAt(b *Box) bool {
+	if w.opts.CollisionFunc != nil {
+		reach := w.opts.CollisionReach
+		if reach <= 0 {
+			// One cell around the box
+			reach = math.Max(w.grid.rw, w.grid.rh)
+		}
+		colliding, _ := w.grid.TestCollisionNear(b, reach, w.opts.CollisionFunc)
+		return colliding
+	}
+	colliding, _ := w.grid.TestCollision(b, (*Box).overlaps)
+	return colliding
+}
+
+// test a series of points on a circle, rotating the word by 90 degrees if it does not fit and RotateToFit is set
 func (w *Wordcloud) testRadius(radius float64, points []point, width float64, height float64) res {
+	r := w.testPoints(radius, points, width, height, 0)
+	if r.failed && w.opts.RotateToFit {
+		tries := r.tries
+		r = w.testPoints(radius, points, height, width, 90)
+		r.tries += tries
+		if !r.failed {
+			r.angle = 90
+		}
+	}
+	return r
+}
+
+// test a series of points on a circle and returns as soon as there's a match. With BalanceFill, returns the match
+// with the fewest words around it instead. angle is 90 if the box is the one of a word reading upwards.
+func (w *Wordcloud) testPoints(radius float64, points []point, width float64, height float64, angle float64) res {
 	var box Box
 	var x, y float64
+	best := res{failed: true}
+	bestOccupancy := 0
 
-	for _, p := range points {
-		y = p.y
-		x = p.x
+	for i, p := range points {
+		y = w.bandY(p.y + w.offset.y)
+		x = p.x + w.offset.x
+
+		if rotation := w.pointAngle(x, y, radius); rotation != 0 {
+			if w.testRotated(x, y, width, height, rotation) {
+				return res{x: x, y: y, angle: rotation, failed: false, radius: radius, tries: i + 1}
+			}
+			continue
+		}
 
 		// Is that position available?
 		box.Top = y + height/2
@@ -370,26 +1033,52 @@ func (w *Wordcloud) testRadius(radius float64, points []point, width float64, he
 		box.Right = x + width/2
 		box.Bottom = y - height/2
 
-		if !box.fits(w.width, w.height) {
+		if !w.fits(w.withDescent(&box, angle)) {
 			continue
 		}
-		colliding, _ := w.grid.TestCollision(&box, func(a *Box, b *Box) bool {
-			return a.overlaps(b)
-		})
+		colliding := w.collides(&box)
 
 		if !colliding {
+			if w.opts.BalanceFill {
+				// Keep looking for the emptiest spot of the circle
+				o := w.grid.Occupancy(&Box{
+					box.Top + height,
+					box.Left - width,
+					box.Right + width,
+					box.Bottom - height,
+				})
+				if best.failed || o < bestOccupancy {
+					best = res{x: x, y: y, failed: false, radius: radius}
+					bestOccupancy = o
+				}
+				continue
+			}
 			return res{
 				x:      x,
 				y:      y,
 				failed: false,
 				radius: radius,
+				tries:  i + 1,
 			}
 		}
 	}
+	if !best.failed {
+		best.tries = len(points)
+		return best
+	}
 	return res{
 		x:      x,
 		y:      y,
 		failed: true,
 		radius: radius,
+		tries:  len(points),
 	}
 }
+
+// Statistics of a word placement
+type attempt struct {
+	// Number of positions tested
+	tries int
+	// Distance of the word to the center of the canvas, negative if it was not placed
+	radius float64
+}
@@ -1,12 +1,18 @@
 package wordclouds
 
 import (
+	"fmt"
+	"image"
 	"image/color"
 	"image/png"
+	"math"
+	"math/rand"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fogleman/gg"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
@@ -76,3 +82,720 @@ func TestWordcloud_Draw(t *testing.T) {
 	// Don't forget to close files
 	outputFile.Close()
 }
+
+func loadTestWords(tb testing.TB) map[string]int {
+	content, err := os.ReadFile("testdata/input.yaml")
+	assert.NoError(tb, err)
+	inputWords := make(map[string]int, 0)
+	err = yaml.Unmarshal(content, &inputWords)
+	assert.NoError(tb, err)
+	return inputWords
+}
+
+// newTestCloud creates a cloud of the words, or of the test words if nil, on a 400x400 canvas with the test font and
+// black words. The options are applied after these.
+func newTestCloud(tb testing.TB, words map[string]int, options ...Option) *Wordcloud {
+	if words == nil {
+		words = loadTestWords(tb)
+	}
+	return NewWordcloud(words, append([]Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+	}, options...)...)
+}
+
+func TestWordcloud_LineSpacing(t *testing.T) {
+	for _, spacing := range []float64{1, 1.5, 2} {
+		w := newTestCloud(t, map[string]int{"two\nlines": 10},
+			LineSpacing(spacing),
+		)
+		w.setFont("two\nlines", 40)
+		_, height := w.measureString(w.dc, "two\nlines")
+		// The second line starts one line spacing below the first one
+		assert.InDelta(t, w.dc.FontHeight()*(1+spacing), height, 0.01)
+	}
+}
+
+func TestWordcloud_EdgeMargin(t *testing.T) {
+	words := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		words[fmt.Sprintf("word%d", i)] = 100 - i
+	}
+	margin := 40.0
+	w := newTestCloud(t, words,
+		FontMaxSize(60),
+		FontMinSize(10),
+		Height(300),
+		EdgeMargin(margin),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	for _, pw := range w.placed {
+		assert.GreaterOrEqual(t, pw.bounds.Left, margin, pw.word)
+		assert.GreaterOrEqual(t, pw.bounds.Bottom, margin, pw.word)
+		assert.LessOrEqual(t, pw.bounds.Right, 400-margin, pw.word)
+		assert.LessOrEqual(t, pw.bounds.Top, 300-margin, pw.word)
+	}
+}
+
+func BenchmarkWordcloud_Draw(b *testing.B) {
+	words := loadTestWords(b)
+	for _, prefetch := range []bool{false, true} {
+		b.Run(fmt.Sprintf("prefetch=%v", prefetch), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				w := newTestCloud(b, words,
+					FontMaxSize(150),
+					FontMinSize(15),
+					Width(1024),
+					Height(1024),
+				)
+				w.prefetchFonts = prefetch
+				w.Draw()
+			}
+		})
+	}
+}
+
+func TestWordcloud_ShowCounts(t *testing.T) {
+	widths := make(map[bool]float64)
+	for _, show := range []bool{false, true} {
+		w := newTestCloud(t, map[string]int{"golang": 42},
+			FontMaxSize(40),
+			ShowCounts(show),
+		)
+		w.Draw()
+		assert.Len(t, w.placed, 1)
+		pw := w.placed[0]
+		widths[show] = pw.box.Right - pw.box.Left
+
+		text := w.text(pw.wordCount)
+		if show {
+			assert.Equal(t, "golang (42)", text)
+		} else {
+			assert.Equal(t, "golang", text)
+		}
+		// The word is placed in a box as wide as the drawn text
+		w.setFont(text, pw.size)
+		width, _ := w.measureString(w.dc, text)
+		assert.InDelta(t, width+5, widths[show], 0.01)
+	}
+	assert.Greater(t, widths[true], widths[false])
+}
+
+func BenchmarkWordcloud_GridCellSize(b *testing.B) {
+	small := make(map[string]int)
+	for i := 0; i < 500; i++ {
+		small[fmt.Sprintf("word%d", i)] = 1 + i%5
+	}
+	inputs := map[string]map[string]int{"input": loadTestWords(b), "small": small}
+	for _, name := range []string{"input", "small"} {
+		for _, auto := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/auto=%v", name, auto), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					w := newTestCloud(b, inputs[name],
+						FontMaxSize(150),
+						FontMinSize(15),
+						Width(1024),
+						Height(1024),
+						AutoGridCellSize(auto),
+					)
+					w.Draw()
+				}
+			})
+		}
+	}
+}
+
+func TestWordcloud_DominantWordSpace(t *testing.T) {
+	words := map[string]int{"dominant": 100}
+	for i := 0; i < 60; i++ {
+		words[fmt.Sprintf("w%d", i)] = 10
+	}
+	ratio := 0.5
+	w := newTestCloud(t, words,
+		FontMaxSize(60),
+		FontMinSize(15),
+		Width(600),
+		Height(600),
+		DominantWordSpace(ratio, 50),
+	)
+	w.Draw()
+
+	big := w.placed[0]
+	assert.Equal(t, "dominant", big.word)
+	space := ratio * big.size
+	reserved := &Box{big.box.Top + space, big.box.Left - space, big.box.Right + space, big.box.Bottom - space}
+	assert.Greater(t, len(w.placed), 10)
+	for _, pw := range w.placed[1:] {
+		assert.False(t, pw.box.overlaps(reserved), pw.word)
+	}
+}
+
+func TestWordcloud_BalanceFill(t *testing.T) {
+	// Squared deviations of the number of words in each quadrant from the mean, over clouds of growing size
+	unevenness := func(balance bool) float64 {
+		total := 0.0
+		for _, n := range []int{16, 24, 32, 48} {
+			words := make(map[string]int)
+			for i := 0; i < n; i++ {
+				words[fmt.Sprintf("word%d", i)] = 100 - i
+			}
+			w := newTestCloud(t, words,
+				FontMaxSize(14),
+				FontMinSize(14),
+				// A strip splitting the canvas in two halves
+				MaskBoxes([]*Box{{400, 190, 210, 0}}),
+				BalanceFill(balance),
+			)
+			w.Draw()
+			assert.Len(t, w.placed, n)
+
+			var quadrants [4]float64
+			for _, pw := range w.placed {
+				q := 0
+				if pw.x > 200 {
+					q++
+				}
+				if pw.y > 200 {
+					q += 2
+				}
+				quadrants[q]++
+			}
+			for _, count := range quadrants {
+				total += (count - float64(n)/4) * (count - float64(n)/4)
+			}
+		}
+		return total
+	}
+	assert.Less(t, unevenness(true), unevenness(false))
+}
+
+func TestWordcloud_RotateToFit(t *testing.T) {
+	// Only a tall and narrow column is free in the middle of the canvas
+	mask := []*Box{
+		{400, 0, 170, 0},
+		{400, 230, 400, 0},
+	}
+	draw := func(rotate bool) *Wordcloud {
+		w := newTestCloud(t, map[string]int{"horizontal": 1},
+			FontMaxSize(40),
+			MaskBoxes(mask),
+			RotateToFit(rotate),
+		)
+		w.Draw()
+		return w
+	}
+
+	assert.Empty(t, draw(false).PlacedWords())
+
+	placed := draw(true).PlacedWords()
+	assert.Len(t, placed, 1)
+	assert.Equal(t, 90.0, placed[0].Angle)
+	assert.Greater(t, placed[0].Box.h(), placed[0].Box.w())
+	assert.Greater(t, placed[0].Box.Left, 170.0)
+	assert.Less(t, placed[0].Box.Right, 230.0)
+}
+
+func TestWordcloud_RandomMaxTries(t *testing.T) {
+	words := make(map[string]int)
+	for i := 0; i < 20; i++ {
+		words[fmt.Sprintf("word%d", i)] = 100 - i
+	}
+	// On a canvas covered by a mask, every word tried uses all of its tries. The default tries go down to 1000 on a
+	// full canvas.
+	for _, maxTries := range []int{50, 0} {
+		w := newTestCloud(t, words,
+			FontMaxSize(30),
+			MaskBoxes([]*Box{{400, 0, 400, 0}}),
+			RandomPlacement(true),
+			RandomMaxTries(maxTries),
+		)
+		w.Draw()
+
+		limit := maxTries
+		if limit == 0 {
+			limit = minRandomMaxTries
+		}
+		tries := w.Result().RandomTries
+		assert.Empty(t, w.Result().Placed)
+		assert.Greater(t, tries, 0)
+		assert.Zero(t, tries%limit)
+		assert.LessOrEqual(t, tries, limit*len(words))
+	}
+}
+
+func TestWordcloud_FillRatio(t *testing.T) {
+	words := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		words[fmt.Sprintf("word%d", i)] = 200 - i
+	}
+	w := newTestCloud(t, words,
+		FontMaxSize(60),
+		FontMinSize(20),
+		Width(300),
+		Height(200),
+	)
+	assert.Equal(t, 0.0, w.FillRatio())
+	assert.False(t, w.IsFull())
+
+	w.Draw()
+	assert.Greater(t, w.FillRatio(), 0.0)
+	assert.LessOrEqual(t, w.FillRatio(), 1.0)
+	assert.True(t, w.IsFull())
+}
+
+func TestWordcloud_DisplayTransform(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"hello": 2, "world": 1},
+		FontMaxSize(60),
+		Height(300),
+		DisplayTransform(strings.ToUpper),
+	)
+	w.Draw()
+
+	assert.ElementsMatch(t, []string{"hello", "world"}, w.Result().Placed)
+	for _, pw := range w.placed {
+		assert.Equal(t, strings.ToUpper(pw.word), pw.text)
+	}
+}
+
+func TestWordcloud_SmallCanvas(t *testing.T) {
+	for _, random := range []bool{false, true} {
+		for _, size := range []int{0, 1, 10, 50} {
+			t.Run(fmt.Sprintf("%dx%d/random=%v", size, size, random), func(t *testing.T) {
+				w := newTestCloud(t, map[string]int{"hello": 10, "world": 5, "a": 1},
+					FontMaxSize(100),
+					FontMinSize(10),
+					Width(size),
+					Height(size),
+					RetryPasses(3),
+					RandomPlacement(random),
+				)
+				done := make(chan image.Image)
+				go func() {
+					done <- w.Draw()
+				}()
+				select {
+				case img := <-done:
+					assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+				case <-time.After(5 * time.Second):
+					t.Fatal("Draw did not return")
+				}
+			})
+		}
+	}
+}
+
+func TestWordcloud_NonPositiveCounts(t *testing.T) {
+	words := map[string]int{"positive": 10, "zero": 0, "negative": -3}
+
+	w := newTestCloud(t, words,
+		FontMaxSize(40),
+		FontMinSize(10),
+	)
+	w.Draw()
+	assert.Equal(t, []string{"positive"}, w.Result().Placed)
+
+	w = newTestCloud(t, words,
+		FontMaxSize(40),
+		FontMinSize(10),
+		NonPositiveCounts(ClampNonPositive),
+	)
+	w.Draw()
+	assert.ElementsMatch(t, []string{"positive", "zero", "negative"}, w.Result().Placed)
+	for _, pw := range w.placed {
+		if pw.count <= 0 {
+			assert.Equal(t, 10.0, pw.size, pw.word)
+		}
+	}
+
+	// Nothing left to draw
+	w = NewWordcloud(map[string]int{"zero": 0, "negative": -3}, FontFile("testdata/Roboto-Regular.ttf"))
+	w.Draw()
+	assert.Empty(t, w.Result().Placed)
+}
+
+func TestWordcloud_WithContext(t *testing.T) {
+	dc := gg.NewContext(300, 200)
+	w := newTestCloud(t, map[string]int{"hello": 2, "world": 1},
+		FontMaxSize(40),
+		Width(300),
+		Height(200),
+		WithContext(dc),
+	)
+	assert.Same(t, dc.Image(), w.Draw())
+
+	assert.Panics(t, func() {
+		NewWordcloud(map[string]int{"hello": 2}, Width(400), Height(200), WithContext(dc))
+	})
+}
+
+func TestWordcloud_MeasureWord(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"hello": 10, "world": 5},
+		FontMaxSize(40),
+	)
+	width, height := w.MeasureWord("hello", 10)
+	smallWidth, smallHeight := w.MeasureWord("hello", 5)
+	assert.Greater(t, width, smallWidth)
+	assert.Greater(t, height, smallHeight)
+
+	w.Draw()
+	for _, pw := range w.placed {
+		width, height := w.MeasureWord(pw.word, pw.count)
+		assert.InDelta(t, pw.box.Right-pw.box.Left, width, 1e-9, pw.word)
+		assert.InDelta(t, pw.box.Top-pw.box.Bottom, height, 1e-9, pw.word)
+	}
+}
+
+func TestWordcloud_Reset(t *testing.T) {
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(60),
+		FontMinSize(10),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(300),
+	}
+	words := loadTestWords(t)
+
+	fresh := NewWordcloud(words, options...)
+	rand.Seed(42)
+	expected := fresh.Draw()
+
+	w := NewWordcloud(map[string]int{"other": 3, "words": 2}, options...)
+	w.Draw()
+	w.Reset(words)
+	rand.Seed(42)
+	assert.Equal(t, expected, w.Draw())
+	assert.Equal(t, fresh.Result(), w.Result())
+}
+
+func TestWordcloud_BoxesOnly(t *testing.T) {
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	w := newTestCloud(t, nil,
+		Colors([]color.Color{color.RGBA{R: 255, A: 255}}),
+		BackgroundColor(white),
+		FontMaxSize(30),
+		BoxesOnly(true),
+	)
+	img := w.Draw()
+
+	// The red glyphs of the words are not drawn, only the gray outlines of their boxes
+	for x := 0; x < 400; x++ {
+		for y := 0; y < 400; y++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			assert.True(t, r == g && g == b, "colored pixel at %d, %d", x, y)
+		}
+	}
+	// Words this small have a single box each, so the boxes do not overlap
+	boxes := w.grid.Boxes(false)
+	assert.NotEmpty(t, boxes)
+	for _, b := range boxes {
+		middle := int((b.Top + b.Bottom) / 2)
+		assert.NotEqual(t, color.Color(white), img.At(int(b.Left), middle))
+		assert.Equal(t, color.Color(white), img.At(int((b.Left+b.Right)/2), middle))
+	}
+}
+
+func TestWordcloud_PlacementRadius(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(40),
+		FontMinSize(10),
+		MinRadius(80),
+		MaxRadius(150),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.placed)
+	assert.NotEmpty(t, w.Result().Skipped)
+	for _, pw := range w.placed {
+		distance := math.Hypot(pw.x-200, pw.y-200)
+		assert.GreaterOrEqual(t, distance, 80.0-1e-9, pw.word)
+		assert.LessOrEqual(t, distance, 150.0+1e-9, pw.word)
+	}
+}
+
+func TestWordcloud_Telemetry(t *testing.T) {
+	radii := make(map[string]float64)
+	tries := 0
+	w := newTestCloud(t, map[string]int{"hello": 10, "world": 5, "toolongtofitonthecanvas": 1},
+		FontMaxSize(40),
+		FontMinSize(40),
+		Width(300),
+		Height(300),
+		Telemetry(func(word string, n int, radius float64, dur time.Duration) {
+			radii[word] = radius
+			tries += n
+			assert.True(t, dur > 0)
+		}),
+	)
+	w.Draw()
+
+	assert.Len(t, radii, 3)
+	assert.Greater(t, tries, 2)
+	assert.Less(t, radii["toolongtofitonthecanvas"], 0.0)
+	for _, pw := range w.placed {
+		assert.InDelta(t, math.Hypot(pw.x-150, pw.y-150), radii[pw.word], 1e-6, pw.word)
+	}
+}
+
+func TestWordcloud_WordTimeout(t *testing.T) {
+	// The only room left is in a corner, at the end of the spiral
+	mask := []*Box{{1000, 0, 1000, 150}, {1000, 0, 850, 0}}
+	place := func(options ...Option) (placed bool, tries int) {
+		w := NewWordcloud(map[string]int{"slow": 1}, append([]Option{
+			FontFile("testdata/Roboto-Regular.ttf"),
+			Colors([]color.Color{color.Black}),
+			FontMaxSize(40),
+			FontMinSize(40),
+			Width(1000),
+			Height(1000),
+			MaskBoxes(mask),
+			Telemetry(func(word string, n int, radius float64, dur time.Duration) {
+				tries = n
+			}),
+		}, options...)...)
+		w.Draw()
+		return len(w.placed) == 1, tries
+	}
+
+	placed, tries := place()
+	assert.True(t, placed)
+	capped, cappedTries := place(WordTimeout(time.Microsecond))
+	assert.False(t, capped)
+	assert.Less(t, cappedTries, tries)
+}
+
+func TestWordcloud_PlacementBias(t *testing.T) {
+	draw := func(bias float64) placedWord {
+		w := newTestCloud(t, nil,
+			FontMaxSize(60),
+			FontMinSize(10),
+			Width(600),
+			Height(600),
+			PlacementBias(bias),
+		)
+		w.Draw()
+		return w.placed[0]
+	}
+	centered := draw(0)
+	biased := draw(0.5)
+
+	assert.Less(t, biased.x, centered.x)
+	assert.Less(t, biased.y, centered.y)
+}
+
+func TestWordcloud_CollisionFunc(t *testing.T) {
+	const gap = 10
+	apart := func(a *Box, b *Box) bool {
+		return a.Left-gap <= b.Right && a.Right+gap >= b.Left && a.Top+gap >= b.Bottom && a.Bottom-gap <= b.Top
+	}
+	closest := func(w *Wordcloud) float64 {
+		distance := math.Inf(1)
+		for j := range w.placed {
+			for i := 0; i < j; i++ {
+				for _, a := range w.placed[i].boxes {
+					for _, b := range w.placed[j].testedBoxes() {
+						dx := math.Max(a.Left-b.Right, b.Left-a.Right)
+						dy := math.Max(a.Bottom-b.Top, b.Bottom-a.Top)
+						distance = math.Min(distance, math.Max(dx, dy))
+					}
+				}
+			}
+		}
+		return distance
+	}
+
+	options := []Option{
+		FontFile("testdata/Roboto-Regular.ttf"),
+		FontMaxSize(40),
+		FontMinSize(10),
+		Colors([]color.Color{color.Black}),
+		Width(400),
+		Height(400),
+	}
+	w := NewWordcloud(loadTestWords(t), options...)
+	w.Draw()
+	assert.Less(t, closest(w), float64(gap))
+
+	w = NewWordcloud(loadTestWords(t), append(options, CollisionFunc(apart))...)
+	w.Draw()
+	assert.NotEmpty(t, w.placed)
+	assert.Greater(t, closest(w), float64(gap))
+
+	// The gap is larger than the cells, the boxes one cell away are not enough
+	options = append(options, GridCellSize(4), CollisionFunc(apart))
+	w = NewWordcloud(loadTestWords(t), options...)
+	w.Draw()
+	assert.Less(t, closest(w), float64(gap))
+	w = NewWordcloud(loadTestWords(t), append(options, CollisionReach(gap))...)
+	w.Draw()
+	assert.NotEmpty(t, w.placed)
+	assert.Greater(t, closest(w), float64(gap))
+}
+
+func TestWordcloud_OnWordDropped(t *testing.T) {
+	type drop struct {
+		word  string
+		count int
+		size  float64
+	}
+	var drops []drop
+	w := newTestCloud(t, map[string]int{"enormous": 10, "fits": 5},
+		FontMaxSize(200),
+		FontMinSize(20),
+		Width(300),
+		Height(300),
+		RetryPasses(1),
+		OnWordDropped(func(word string, count int, attemptedSize float64) {
+			drops = append(drops, drop{word, count, attemptedSize})
+		}),
+	)
+	w.Draw()
+
+	assert.Equal(t, []drop{{"enormous", 10, 200}, {"enormous", 10, 160}}, drops)
+	assert.Equal(t, []string{"enormous"}, w.Result().Skipped)
+}
+
+func TestWordcloud_LineHeightFactor(t *testing.T) {
+	placed := make(map[float64]*Wordcloud)
+	for _, factor := range []float64{1, 0.8} {
+		w := newTestCloud(t, nil,
+			FontMaxSize(40),
+			FontMinSize(20),
+			Width(300),
+			Height(300),
+			LineHeightFactor(factor),
+		)
+		w.Draw()
+		placed[factor] = w
+	}
+
+	dc := gg.NewContext(1, 1)
+	dc.SetFontFace(placed[1].face("float64", 40))
+	_, height := dc.MeasureString("float64")
+	assert.InDelta(t, height+5, placed[1].placed[0].height, 1e-9)
+	assert.InDelta(t, 0.8*height+5, placed[0.8].placed[0].height, 1e-9)
+	assert.Greater(t, len(placed[0.8].placed), len(placed[1].placed))
+}
+
+func TestWordcloud_HitBoxPadding(t *testing.T) {
+	w := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+		HitBoxPadding(8),
+	)
+	img := w.Draw()
+
+	for _, pw := range w.PlacedWords() {
+		assert.Equal(t, math.Min(pw.Box.Top+8, 400), pw.HitBox.Top)
+		assert.Equal(t, math.Max(pw.Box.Left-8, 0), pw.HitBox.Left)
+		assert.Equal(t, math.Min(pw.Box.Right+8, 400), pw.HitBox.Right)
+		assert.Equal(t, math.Max(pw.Box.Bottom-8, 0), pw.HitBox.Bottom)
+	}
+	normalized := w.PlacedWordsNormalized()[0]
+	assert.InDelta(t, (w.placed[0].box.Left-8)/400, normalized.HitBox.Left, 1e-9)
+
+	// The layout is the one without padding
+	plain := newTestCloud(t, nil,
+		FontMaxSize(60),
+		FontMinSize(10),
+	)
+	assert.Equal(t, plain.Draw(), img)
+}
+
+func TestWordcloud_ExcludeWords(t *testing.T) {
+	words := loadTestWords(t)
+	words["Width"] = 30
+	w := newTestCloud(t, words,
+		FontMaxSize(40),
+		FontMinSize(10),
+		ExcludeWords([]string{"WIDTH", "float64", "notaword"}),
+	)
+	w.Draw()
+
+	assert.NotEmpty(t, w.PlacedWords())
+	for _, pw := range w.PlacedWords() {
+		assert.NotEqual(t, "width", strings.ToLower(pw.Word))
+		assert.NotEqual(t, "float64", pw.Word)
+	}
+	assert.NotContains(t, w.Result().Skipped, "float64")
+	assert.Equal(t, 80, words["float64"])
+}
+
+func TestNewWordcloudFromTokens(t *testing.T) {
+	tokens := []string{"Go", "go", "cloud", "go", "the", "cloud", "", "The"}
+	w := NewWordcloudFromTokens(tokens, FontFile("testdata/Roboto-Regular.ttf"))
+	assert.Equal(t, map[string]int{"Go": 1, "go": 2, "cloud": 2, "the": 1, "The": 1}, w.wordList)
+
+	w = NewWordcloudFromTokens(tokens,
+		FontFile("testdata/Roboto-Regular.ttf"),
+		TokenOptions(Normalizer(strings.ToLower)),
+		ExcludeWords([]string{"the"}),
+	)
+	assert.Equal(t, map[string]int{"go": 3, "cloud": 2, "the": 2}, w.wordList)
+	assert.Len(t, w.sortedWordList, 2)
+	assert.Equal(t, wordCount{word: "go", count: 3, size: w.sortedWordList[0].size, tier: -1}, w.sortedWordList[0])
+}
+
+func TestWordcloud_WordPadding(t *testing.T) {
+	words := map[string]int{"NASA": 10, "a": 9, "b": 9, "c": 9, "d": 9, "e": 9, "f": 9, "g": 9}
+	// Distance from the box of the first word, without its padding, to the closest box of the other words
+	closest := func(padding float64) float64 {
+		w := newTestCloud(t, words,
+			FontMaxSize(20),
+			WordPadding(map[string]float64{"NASA": padding}),
+		)
+		w.Draw()
+		assert.Len(t, w.placed, len(words))
+		assert.Equal(t, "NASA", w.placed[0].word)
+		b := w.placed[0].box
+		first := Box{b.Top - padding, b.Left + padding, b.Right - padding, b.Bottom + padding}
+		gap := math.Inf(1)
+		for _, pw := range w.placed[1:] {
+			dx := math.Max(0, math.Max(first.Left-pw.box.Right, pw.box.Left-first.Right))
+			dy := math.Max(0, math.Max(first.Bottom-pw.box.Top, pw.box.Bottom-first.Top))
+			gap = math.Min(gap, math.Max(dx, dy))
+		}
+		return gap
+	}
+
+	// Small enough for the padded word to keep a single box
+	assert.Less(t, closest(0), 5.0)
+	assert.True(t, closest(5) >= 5)
+}
+
+func TestWordcloud_WordPaddingCase(t *testing.T) {
+	width := func(padding map[string]float64) float64 {
+		w := newTestCloud(t, map[string]int{"NASA": 7},
+			FontMaxSize(20),
+			WordPadding(padding),
+		)
+		w.Draw()
+		assert.Len(t, w.placed, 1)
+		return w.placed[0].width
+	}
+	assert.InDelta(t, width(nil)+10, width(map[string]float64{"nasa": 5}), 1e-9)
+}
+
+func TestWordcloud_MeasureWordPadding(t *testing.T) {
+	w := newTestCloud(t, map[string]int{"NASA": 10, "word": 5},
+		FontMaxSize(40),
+		WordPadding(map[string]float64{"NASA": 6}),
+	)
+	w.Draw()
+	assert.Len(t, w.placed, 2)
+	for _, pw := range w.placed {
+		width, height := w.MeasureWord(pw.word, pw.count)
+		assert.InDelta(t, pw.box.Right-pw.box.Left, width, 1e-9, pw.word)
+		assert.InDelta(t, pw.box.Top-pw.box.Bottom, height, 1e-9, pw.word)
+	}
+
+	unpadded := NewWordcloud(map[string]int{"NASA": 10}, FontFile("testdata/Roboto-Regular.ttf"), FontMaxSize(40))
+	width, height := w.MeasureWord("NASA", 10)
+	unpaddedWidth, unpaddedHeight := unpadded.MeasureWord("NASA", 10)
+	assert.InDelta(t, unpaddedWidth+12, width, 1e-9)
+	assert.InDelta(t, unpaddedHeight+12, height, 1e-9)
+}